@@ -0,0 +1,71 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeys
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+const (
+	flagName      = "name"
+	flagExpiresIn = "expires-in"
+)
+
+func NewCreateAPIKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api-key --name=NAME [--expires-in=720h] [--project=PROJECT_ID | --workspace=WORKSPACE_ID]",
+		Short: "Create a new API key",
+		Long: `Create a new API key for a project, or for a workspace if only --workspace is given.
+
+The secret of the key is printed only once. It can not be retrieved later on, so store it right away.
+With --quiet, only the secret is printed.`,
+		Example: `$ ory create api-key --project <project-id> --name ci --expires-in 720h
+$ ORY_PROJECT_API_KEY=$(ory create api-key --name ci --quiet)
+$ ory create api-key --workspace <workspace-id> --name ci --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			name := flagx.MustGetString(cmd, flagName)
+			if name == "" {
+				return errors.New("--name is required")
+			}
+			expiresIn := flagx.MustGetDuration(cmd, flagExpiresIn)
+
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			s, err := keyScope(cmd, h)
+			if err != nil {
+				return err
+			}
+
+			key, err := s.create(ctx, h, name, expiresIn)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+
+			_, _ = fmt.Fprintln(h.VerboseErrWriter, "API key created successfully! Store the secret now, it will not be shown again.")
+			cmdx.PrintRow(cmd, outputCreated{key: *key})
+			return nil
+		},
+	}
+
+	client.RegisterProjectFlag(cmd.Flags())
+	client.RegisterWorkspaceFlag(cmd.Flags())
+	cmdx.RegisterFormatFlags(cmd.Flags())
+	cmd.Flags().String(flagName, "", "The name of the API key.")
+	cmd.Flags().Duration(flagExpiresIn, 0, "Let the API key expire after this duration, for example 720h. By default, the key does not expire.")
+
+	return cmd
+}
@@ -0,0 +1,44 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeys
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/x/cmdx"
+)
+
+func NewDeleteAPIKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api-key <id> [--project=PROJECT_ID | --workspace=WORKSPACE_ID]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Delete the API key with the given ID",
+		Long:  "Delete the API key with the given ID from a project, or from a workspace if only --workspace is given.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			s, err := keyScope(cmd, h)
+			if err != nil {
+				return err
+			}
+
+			if err := s.delete(cmd.Context(), h, args[0]); err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+
+			_, _ = fmt.Fprintln(h.VerboseErrWriter, "API key deleted successfully!")
+			return nil
+		},
+	}
+
+	client.RegisterProjectFlag(cmd.Flags())
+	client.RegisterWorkspaceFlag(cmd.Flags())
+	return cmd
+}
@@ -0,0 +1,127 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	cloud "github.com/ory/client-go"
+)
+
+// apiKey is a project or a workspace API key. The value is only known right
+// after the key was created.
+type apiKey struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	ProjectID      string     `json:"project_id,omitempty"`
+	WorkspaceID    string     `json:"workspace_id,omitempty"`
+	LastCharacters string     `json:"last_characters,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	Value          string     `json:"value,omitempty"`
+}
+
+// scope is the project or the workspace the API keys belong to. Exactly one of
+// the IDs is set.
+type scope struct {
+	projectID, workspaceID string
+}
+
+// keyScope determines the scope from the flags. The keys belong to the
+// workspace only if `--workspace` is given without `--project`, because
+// otherwise `--workspace` merely tells where to look for the project.
+func keyScope(cmd *cobra.Command, h *client.CommandHelper) (scope, error) {
+	if cmd.Flags().Changed(client.FlagWorkspace) && !cmd.Flags().Changed(client.FlagProject) {
+		workspaceID := h.WorkspaceID()
+		if workspaceID == nil {
+			return scope{}, errors.New("no workspace found, please specify a valid workspace with --workspace")
+		}
+		return scope{workspaceID: *workspaceID}, nil
+	}
+
+	projectID, err := h.ProjectID()
+	if err != nil {
+		return scope{}, err
+	}
+	return scope{projectID: projectID}, nil
+}
+
+func (s scope) create(ctx context.Context, h *client.CommandHelper, name string, expiresIn time.Duration) (*apiKey, error) {
+	if s.workspaceID != "" {
+		key, err := h.CreateWorkspaceAPIKey(ctx, s.workspaceID, name, expiresIn)
+		if err != nil {
+			return nil, err
+		}
+		return new(s.fromWorkspaceKey(*key)), nil
+	}
+
+	key, err := h.CreateProjectAPIKey(ctx, s.projectID, name, expiresIn)
+	if err != nil {
+		return nil, err
+	}
+	return new(s.fromProjectKey(*key)), nil
+}
+
+func (s scope) list(ctx context.Context, h *client.CommandHelper) ([]apiKey, error) {
+	var keys []apiKey
+	if s.workspaceID != "" {
+		ks, err := h.ListWorkspaceAPIKeys(ctx, s.workspaceID)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range ks {
+			keys = append(keys, s.fromWorkspaceKey(k))
+		}
+	} else {
+		ks, err := h.ListProjectAPIKeys(ctx, s.projectID)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range ks {
+			keys = append(keys, s.fromProjectKey(k))
+		}
+	}
+
+	// Secrets are only ever shown when a key is created.
+	for i := range keys {
+		keys[i].Value = ""
+	}
+	return keys, nil
+}
+
+func (s scope) delete(ctx context.Context, h *client.CommandHelper, keyID string) error {
+	if s.workspaceID != "" {
+		return h.DeleteWorkspaceAPIKey(ctx, s.workspaceID, keyID)
+	}
+	return h.DeleteProjectAPIKey(ctx, s.projectID, keyID)
+}
+
+func (s scope) fromProjectKey(k cloud.ProjectApiKey) apiKey {
+	return apiKey{
+		ID:             k.Id,
+		Name:           k.Name,
+		ProjectID:      s.projectID,
+		LastCharacters: k.GetLastCharacters(),
+		CreatedAt:      k.CreatedAt,
+		ExpiresAt:      k.ExpiresAt,
+		Value:          k.GetValue(),
+	}
+}
+
+func (s scope) fromWorkspaceKey(k cloud.WorkspaceApiKey) apiKey {
+	return apiKey{
+		ID:             k.Id,
+		Name:           k.Name,
+		WorkspaceID:    s.workspaceID,
+		LastCharacters: k.GetLastCharacters(),
+		CreatedAt:      k.CreatedAt,
+		ExpiresAt:      k.ExpiresAt,
+		Value:          k.GetValue(),
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeys
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/x/cmdx"
+)
+
+func NewListAPIKeysCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api-keys [--project=PROJECT_ID | --workspace=WORKSPACE_ID]",
+		Args:  cobra.NoArgs,
+		Short: "List the API keys of a project or a workspace",
+		Long:  "List the API keys of a project, or of a workspace if only --workspace is given. The secrets are not shown.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			s, err := keyScope(cmd, h)
+			if err != nil {
+				return err
+			}
+
+			keys, err := s.list(cmd.Context(), h)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+
+			cmdx.PrintTable(cmd, outputList(keys))
+			return nil
+		},
+	}
+
+	client.RegisterProjectFlag(cmd.Flags())
+	client.RegisterWorkspaceFlag(cmd.Flags())
+	cmdx.RegisterFormatFlags(cmd.Flags())
+	return cmd
+}
@@ -0,0 +1,133 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeys
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/x/cmdx"
+)
+
+type (
+	output     apiKey
+	outputList []apiKey
+	// outputCreated is a new key including its secret.
+	outputCreated struct{ key apiKey }
+)
+
+var (
+	_ cmdx.TableRow = output{}
+	_ cmdx.Table    = outputList{}
+	_ cmdx.TableRow = outputCreated{}
+)
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return cmdx.None
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func (output) Header() []string {
+	return []string{"ID", "NAME", "LAST CHARACTERS", "CREATED AT", "EXPIRES AT"}
+}
+
+func (o output) Columns() []string {
+	return []string{o.ID, o.Name, o.LastCharacters, formatTime(o.CreatedAt), formatTime(o.ExpiresAt)}
+}
+
+func (o output) Interface() interface{} {
+	return apiKey(o)
+}
+
+func (outputList) Header() []string {
+	return new(output).Header()
+}
+
+func (o outputList) Table() [][]string {
+	rows := make([][]string, len(o))
+	for i, key := range o {
+		rows[i] = output(key).Columns()
+	}
+	return rows
+}
+
+func (o outputList) Interface() interface{} {
+	return o
+}
+
+func (o outputList) Len() int {
+	return len(o)
+}
+
+func (o outputList) MarshalJSON() ([]byte, error) {
+	if o == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]apiKey(o))
+}
+
+func (outputCreated) Header() []string {
+	return []string{"ID", "NAME", "EXPIRES AT", "SECRET"}
+}
+
+func (o outputCreated) Columns() []string {
+	return []string{o.key.ID, o.key.Name, formatTime(o.key.ExpiresAt), o.key.Value}
+}
+
+func (o outputCreated) Interface() interface{} {
+	return o.key
+}
+
+// ID is what `--quiet` prints. That is the secret, because it can not be
+// retrieved later on.
+func (o outputCreated) ID() string {
+	return o.key.Value
+}
+
+const (
+	rotationPending  = "pending"
+	rotationFinished = "finished"
+	rotationFailed   = "failed"
+)
+
+type (
+	// rotationResult is a rotation after `--finish` tried to delete its old key.
+	rotationResult struct {
+		client.APIKeyRotation
+		Status string `json:"status"`
+	}
+	outputRotations []rotationResult
+)
+
+var _ cmdx.Table = outputRotations{}
+
+func (outputRotations) Header() []string {
+	return []string{"OLD KEY", "NEW KEY", "NAME", "DELETE AFTER", "STATUS"}
+}
+
+func (o outputRotations) Table() [][]string {
+	rows := make([][]string, len(o))
+	for i, r := range o {
+		rows[i] = []string{r.OldKeyID, r.NewKeyID, r.Name, formatTime(&r.DeleteAfter), r.Status}
+	}
+	return rows
+}
+
+func (o outputRotations) Interface() interface{} {
+	return o
+}
+
+func (o outputRotations) Len() int {
+	return len(o)
+}
+
+func (o outputRotations) MarshalJSON() ([]byte, error) {
+	if o == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]rotationResult(o))
+}
@@ -0,0 +1,78 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeys
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	cloud "github.com/ory/client-go"
+	"github.com/ory/x/cmdx"
+)
+
+func TestOutput(t *testing.T) {
+	t.Parallel()
+
+	s := scope{projectID: "project-id"}
+	expiresAt := time.Date(2026, 11, 16, 12, 0, 0, 0, time.UTC)
+	created := s.fromProjectKey(cloud.ProjectApiKey{
+		Id:             "key-id",
+		Name:           "ci",
+		LastCharacters: new("cret"),
+		ExpiresAt:      &expiresAt,
+		Value:          new("ory_pat_secret"),
+	})
+
+	print := func(t *testing.T, format string, print func(cmd *cobra.Command)) string {
+		cmd := &cobra.Command{Run: func(cmd *cobra.Command, _ []string) { print(cmd) }}
+		cmdx.RegisterFormatFlags(cmd.Flags())
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		if format == "quiet" {
+			cmd.SetArgs([]string{"--" + cmdx.FlagQuiet})
+		} else {
+			cmd.SetArgs([]string{"--" + cmdx.FlagFormat, format})
+		}
+		require.NoError(t, cmd.Execute())
+		return out.String()
+	}
+
+	t.Run("case=the secret is printed in every format", func(t *testing.T) {
+		for _, format := range []string{"table", "json", "json-pretty", "yaml", "jsonpointer=/value"} {
+			out := print(t, format, func(cmd *cobra.Command) { cmdx.PrintRow(cmd, outputCreated{key: created}) })
+			assert.Equal(t, 1, strings.Count(out, "ory_pat_secret"), "%s: %s", format, out)
+		}
+
+		out := print(t, "json", func(cmd *cobra.Command) { cmdx.PrintRow(cmd, outputCreated{key: created}) })
+		assert.Equal(t, "project-id", gjson.Get(out, "project_id").String())
+		assert.Equal(t, "2026-11-16T12:00:00Z", gjson.Get(out, "expires_at").String())
+	})
+
+	t.Run("case=quiet prints only the secret", func(t *testing.T) {
+		out := print(t, "quiet", func(cmd *cobra.Command) { cmdx.PrintRow(cmd, outputCreated{key: created}) })
+		assert.Equal(t, "ory_pat_secret\n", out)
+	})
+
+	t.Run("case=lists do not contain secrets", func(t *testing.T) {
+		listed := created
+		listed.Value = ""
+		out := print(t, "json", func(cmd *cobra.Command) { cmdx.PrintTable(cmd, outputList{listed}) })
+		assert.False(t, gjson.Get(out, "0.value").Exists(), out)
+		assert.Equal(t, "cret", gjson.Get(out, "0.last_characters").String())
+
+		out = print(t, "json", func(cmd *cobra.Command) { cmdx.PrintTable(cmd, outputList(nil)) })
+		assert.Equal(t, "[]\n", out)
+
+		out = print(t, "table", func(cmd *cobra.Command) { cmdx.PrintTable(cmd, outputList{listed}) })
+		assert.Contains(t, out, "2026-11-16T12:00:00Z")
+		assert.Contains(t, out, "LAST CHARACTERS")
+	})
+}
@@ -0,0 +1,253 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeys
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+const (
+	flagOverlap    = "overlap"
+	flagDeleteOld  = "delete-old"
+	flagFinish     = "finish"
+	flagWarnWithin = "warn-within"
+)
+
+// rotationSuffix marks successor keys. Rotating a successor replaces the
+// suffix instead of adding another one.
+var rotationSuffix = regexp.MustCompile(`-rotated-\d{8}T\d{6}Z$`)
+
+func successorName(name string, now time.Time) string {
+	return rotationSuffix.ReplaceAllString(name, "") + "-rotated-" + now.UTC().Format("20060102T150405Z")
+}
+
+// expiringKeys returns the keys that expire within the window, soonest first.
+func expiringKeys(keys []apiKey, within time.Duration, now time.Time) []apiKey {
+	var expiring []apiKey
+	for _, k := range keys {
+		if k.ExpiresAt != nil && k.ExpiresAt.Before(now.Add(within)) {
+			expiring = append(expiring, k)
+		}
+	}
+	slices.SortFunc(expiring, func(a, b apiKey) int { return a.ExpiresAt.Compare(*b.ExpiresAt) })
+	return expiring
+}
+
+func NewRotateAPIKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api-key {<id> [--overlap=24h | --delete-old] | --finish} [--project=PROJECT_ID | --workspace=WORKSPACE_ID]",
+		Short: "Replace an API key with a new one",
+		Long: `Replace an API key of a project, or of a workspace if only --workspace is given, with a new one.
+
+The new key gets the name of the old key with a "-rotated-<time>" suffix and, unless --expires-in is given, the same lifetime.
+Its secret is printed only once. With --quiet, only the secret is printed.
+
+The old key keeps working for the --overlap, so that its users can switch to the new key.
+Run this command with --finish once the overlap has passed to delete the old keys.
+An old key that was deleted already, for example in the Ory Console, counts as finished. A key can only be rotated once until its rotation is finished, unless --delete-old is set.
+The pending rotations are stored next to the config file. Use --delete-old to delete the old key right away instead.
+
+Keys expiring within --warn-within are reported, so that they can be rotated in time.`,
+		Example: `$ ory rotate api-key <key-id> --overlap 24h
+$ ory rotate api-key --finish
+$ ory rotate api-key <key-id> --delete-old --quiet`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			finish := flagx.MustGetBool(cmd, flagFinish)
+			deleteOld := flagx.MustGetBool(cmd, flagDeleteOld)
+			switch {
+			case finish && len(args) > 0:
+				return errors.New("--finish deletes all old keys whose overlap has passed and does not take an API key ID")
+			case finish && (deleteOld || cmd.Flags().Changed(flagOverlap)):
+				return errors.New("--finish can not be combined with --delete-old or --overlap")
+			case !finish && len(args) == 0:
+				return errors.New("please provide the ID of the API key to rotate, or use --finish")
+			case deleteOld && cmd.Flags().Changed(flagOverlap):
+				return errors.New("--delete-old and --overlap are mutually exclusive")
+			}
+			if flagx.MustGetDuration(cmd, flagOverlap) < 0 {
+				return errors.New("--overlap must not be negative")
+			}
+
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			if finish {
+				return finishRotations(cmd, h, time.Now())
+			}
+			return rotate(cmd, h, args[0], time.Now())
+		},
+	}
+
+	client.RegisterProjectFlag(cmd.Flags())
+	client.RegisterWorkspaceFlag(cmd.Flags())
+	cmdx.RegisterFormatFlags(cmd.Flags())
+	cmd.Flags().Duration(flagOverlap, 24*time.Hour, "Keep the old API key working for this duration.")
+	cmd.Flags().Bool(flagDeleteOld, false, "Delete the old API key right away.")
+	cmd.Flags().Bool(flagFinish, false, "Delete the old API keys whose overlap has passed.")
+	cmd.Flags().Duration(flagExpiresIn, 0, "Let the new API key expire after this duration. Defaults to the lifetime of the old key.")
+	cmd.Flags().Duration(flagWarnWithin, 7*24*time.Hour, "Warn about API keys expiring within this duration. Set to 0 to disable.")
+
+	return cmd
+}
+
+func rotate(cmd *cobra.Command, h *client.CommandHelper, keyID string, now time.Time) error {
+	ctx := cmd.Context()
+
+	s, err := keyScope(cmd, h)
+	if err != nil {
+		return err
+	}
+	keys, err := s.list(ctx, h)
+	if err != nil {
+		return cmdx.PrintOpenAPIError(cmd, err)
+	}
+	i := slices.IndexFunc(keys, func(k apiKey) bool { return k.ID == keyID })
+	if i < 0 {
+		return fmt.Errorf("API key %s does not exist", keyID)
+	}
+	old := keys[i]
+
+	deleteOld := flagx.MustGetBool(cmd, flagDeleteOld)
+	pending, err := h.PendingAPIKeyRotations()
+	if err != nil {
+		return err
+	}
+	if !deleteOld {
+		if err := checkNotPending(pending, old.ID); err != nil {
+			return err
+		}
+	}
+
+	expiresIn := flagx.MustGetDuration(cmd, flagExpiresIn)
+	if !cmd.Flags().Changed(flagExpiresIn) && old.CreatedAt != nil && old.ExpiresAt != nil {
+		expiresIn = old.ExpiresAt.Sub(*old.CreatedAt)
+	}
+	key, err := s.create(ctx, h, successorName(old.Name, now), expiresIn)
+	if err != nil {
+		return cmdx.PrintOpenAPIError(cmd, err)
+	}
+
+	// Print the secret before touching the old key, so that it is not lost
+	// if anything fails from here on.
+	_, _ = fmt.Fprintln(h.VerboseErrWriter, "API key rotated successfully! Store the secret now, it will not be shown again.")
+	cmdx.PrintRow(cmd, outputCreated{key: *key})
+
+	if deleteOld {
+		if err := s.delete(ctx, h, old.ID); err != nil {
+			return cmdx.PrintOpenAPIError(cmd, err)
+		}
+		_, _ = fmt.Fprintf(h.VerboseErrWriter, "Deleted the old API key %s.\n", old.ID)
+	} else {
+		rotation := client.APIKeyRotation{
+			ProjectID:   s.projectID,
+			WorkspaceID: s.workspaceID,
+			OldKeyID:    old.ID,
+			NewKeyID:    key.ID,
+			Name:        old.Name,
+			DeleteAfter: now.Add(flagx.MustGetDuration(cmd, flagOverlap)).UTC().Round(time.Second),
+		}
+		if err := h.SetPendingAPIKeyRotations(append(pending, rotation)); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(h.VerboseErrWriter, "The old API key %s keeps working. Run `ory rotate api-key --finish` after %s to delete it.\n", old.ID, rotation.DeleteAfter.Format(time.RFC3339))
+	}
+
+	keys[i] = *key
+	warnExpiring(cmd, h, keys, now)
+	return nil
+}
+
+// checkNotPending fails if the key is the old key of a pending rotation, as
+// rotating it again would leave the first successor behind.
+func checkNotPending(pending []client.APIKeyRotation, keyID string) error {
+	i := slices.IndexFunc(pending, func(r client.APIKeyRotation) bool { return r.OldKeyID == keyID })
+	if i < 0 {
+		return nil
+	}
+	return fmt.Errorf("API key %s is already being rotated to %s, run `ory rotate api-key --finish` after %s to delete it, or use --delete-old", keyID, pending[i].NewKeyID, pending[i].DeleteAfter.Format(time.RFC3339))
+}
+
+func finishRotations(cmd *cobra.Command, h *client.CommandHelper, now time.Time) error {
+	ctx := cmd.Context()
+
+	pending, err := h.PendingAPIKeyRotations()
+	if err != nil {
+		return err
+	}
+
+	var (
+		remaining []client.APIKeyRotation
+		results   outputRotations
+		failed    int
+		scopes    []scope
+	)
+	for _, r := range pending {
+		s := scope{projectID: r.ProjectID, workspaceID: r.WorkspaceID}
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+
+		if now.Before(r.DeleteAfter) {
+			remaining = append(remaining, r)
+			results = append(results, rotationResult{APIKeyRotation: r, Status: rotationPending})
+			continue
+		}
+		if err := s.delete(ctx, h, r.OldKeyID); errors.Is(err, client.ErrAPIKeyNotFound) {
+			_, _ = fmt.Fprintf(h.VerboseErrWriter, "The old API key %s was deleted already.\n", r.OldKeyID)
+		} else if err != nil {
+			_, _ = fmt.Fprintf(h.VerboseErrWriter, "Unable to delete the old API key %s: %s\n", r.OldKeyID, err)
+			remaining = append(remaining, r)
+			results = append(results, rotationResult{APIKeyRotation: r, Status: rotationFailed})
+			failed++
+			continue
+		}
+		results = append(results, rotationResult{APIKeyRotation: r, Status: rotationFinished})
+	}
+
+	if err := h.SetPendingAPIKeyRotations(remaining); err != nil {
+		return err
+	}
+	cmdx.PrintTable(cmd, results)
+
+	for _, s := range scopes {
+		keys, err := s.list(ctx, h)
+		if err != nil {
+			_, _ = fmt.Fprintf(h.VerboseErrWriter, "Unable to check the API keys for their expiry: %s\n", err)
+			continue
+		}
+		warnExpiring(cmd, h, keys, now)
+	}
+
+	if failed > 0 {
+		return cmdx.FailSilently(cmd)
+	}
+	return nil
+}
+
+func warnExpiring(cmd *cobra.Command, h *client.CommandHelper, keys []apiKey, now time.Time) {
+	within := flagx.MustGetDuration(cmd, flagWarnWithin)
+	if within <= 0 {
+		return
+	}
+	for _, k := range expiringKeys(keys, within, now) {
+		verb := "expires"
+		if k.ExpiresAt.Before(now) {
+			verb = "expired"
+		}
+		_, _ = fmt.Fprintf(h.VerboseErrWriter, "Warning: API key %s (%s) %s at %s.\n", k.ID, k.Name, verb, k.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+}
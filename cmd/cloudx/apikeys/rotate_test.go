@@ -0,0 +1,54 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeys
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+)
+
+func TestSuccessorName(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 17, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	assert.Equal(t, "ci-rotated-20261017T103000Z", successorName("ci", now))
+	assert.Equal(t, "ci-rotated-20261017T103000Z", successorName("ci-rotated-20260917T080000Z", now), "the suffix is replaced")
+	assert.Equal(t, "my-rotated-key-rotated-20261017T103000Z", successorName("my-rotated-key", now))
+}
+
+func TestExpiringKeys(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time { return new(now.Add(d)) }
+	keys := []apiKey{
+		{ID: "never"},
+		{ID: "next-month", ExpiresAt: at(30 * 24 * time.Hour)},
+		{ID: "tomorrow", ExpiresAt: at(24 * time.Hour)},
+		{ID: "expired", ExpiresAt: at(-time.Hour)},
+		{ID: "next-week", ExpiresAt: at(6 * 24 * time.Hour)},
+	}
+
+	var ids []string
+	for _, k := range expiringKeys(keys, 7*24*time.Hour, now) {
+		ids = append(ids, k.ID)
+	}
+	assert.Equal(t, []string{"expired", "tomorrow", "next-week"}, ids)
+	assert.Empty(t, expiringKeys(keys[:2], 7*24*time.Hour, now))
+}
+
+func TestCheckNotPending(t *testing.T) {
+	t.Parallel()
+
+	pending := []client.APIKeyRotation{
+		{ProjectID: "project", OldKeyID: "old", NewKeyID: "new", Name: "ci", DeleteAfter: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)},
+	}
+	assert.NoError(t, checkNotPending(nil, "old"))
+	assert.NoError(t, checkNotPending(pending, "new"), "the successor can be rotated again")
+	assert.EqualError(t, checkNotPending(pending, "old"), "API key old is already being rotated to new, run `ory rotate api-key --finish` after 2026-10-18T12:00:00Z to delete it, or use --delete-old")
+}
@@ -0,0 +1,22 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudx
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/cli/cmd/cloudx/organizations"
+	"github.com/ory/cli/cmd/cloudx/project"
+	"github.com/ory/x/cmdx"
+)
+
+func NewApplyCmd() *cobra.Command {
+	cmd := project.NewProjectApplyCmd()
+	cmd.AddCommand(organizations.NewApplyOrganizationsCmd())
+	client.RegisterConfigFlag(cmd.PersistentFlags())
+	client.RegisterYesFlag(cmd.PersistentFlags())
+	cmdx.RegisterNoiseFlags(cmd.PersistentFlags())
+	return cmd
+}
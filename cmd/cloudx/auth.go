@@ -14,25 +14,59 @@ func NewAuthCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "auth",
 		Short: "Create a new Ory Network account or sign in to an existing account.",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			h, err := client.NewCobraCommandHelper(cmd)
-			if err != nil {
-				return err
-			}
-
-			ac, err := h.GetAuthenticatedConfig(cmd.Context())
-			if err != nil {
-				return err
-			}
-
-			cmdx.PrintRow(cmd, ac)
-			return nil
-		},
+		Long: `Create a new Ory Network account or sign in to an existing account.
+
+On machines without a browser, for example over SSH or in a dev container, use
+` + "`--device`" + ` to confirm the login on another device.
+
+Use the ` + "`--profile`" + ` flag to sign in to several accounts side by side, and
+` + "`ory use profile`" + ` to switch between them.
+
+Session tokens are stored in plaintext in the configuration file by default. Use
+` + "`--credential-store keyring`" + ` to keep them in the operating system keyring, or
+` + "`--credential-store encrypted-file`" + ` to keep them in a passphrase-encrypted file
+(set ` + "`ORY_CREDENTIALS_PASSPHRASE`" + ` to unlock it without a prompt). Tokens already
+stored are moved to the chosen store.`,
+		RunE: runAuth,
 	}
 	client.RegisterConfigFlag(cmd.PersistentFlags())
+	client.RegisterCredentialStoreFlag(cmd.PersistentFlags())
+	client.RegisterDeviceFlag(cmd.PersistentFlags())
 	client.RegisterYesFlag(cmd.PersistentFlags())
 	cmdx.RegisterNoiseFlags(cmd.PersistentFlags())
 	cmdx.RegisterFormatFlags(cmd.Flags())
-	cmd.AddCommand(NewLogoutCmd())
+	cmd.AddCommand(NewLoginCmd(), NewLogoutCmd())
+	return cmd
+}
+
+func NewLoginCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Sign in to an existing Ory Network account.",
+		Example: `$ ory auth login
+
+$ ory auth login --profile acme
+
+$ ory auth login --device
+
+$ ory auth login --credential-store keyring`,
+		RunE: runAuth,
+	}
+	cmdx.RegisterFormatFlags(cmd.Flags())
 	return cmd
 }
+
+func runAuth(cmd *cobra.Command, _ []string) error {
+	h, err := client.NewCobraCommandHelper(cmd)
+	if err != nil {
+		return err
+	}
+
+	ac, err := h.GetAuthenticatedConfig(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	cmdx.PrintRow(cmd, ac)
+	return nil
+}
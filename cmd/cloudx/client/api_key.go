@@ -7,11 +7,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	cloud "github.com/ory/client-go"
 )
 
+// ErrAPIKeyNotFound is returned when deleting an API key that does not exist,
+// for example because it was deleted already.
+var ErrAPIKeyNotFound = errors.New("the API key does not exist")
+
 // CreateProjectAPIKey creates a project API key. If expiresIn is greater than
 // zero, the key is set to expire that duration from now so it is cleaned up
 // automatically on the server side even if local cleanup fails. An expiresIn of
@@ -47,19 +52,47 @@ func (h *CommandHelper) DeleteProjectAPIKey(ctx context.Context, projectID, keyI
 	}
 
 	if res, err := c.ProjectAPI.DeleteProjectApiKey(ctx, projectID, keyID).Execute(); err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("unable to delete project API key: %w", ErrAPIKeyNotFound)
+		}
 		return handleError("unable to delete project API key", res, err)
 	}
 
 	return nil
 }
 
-func (h *CommandHelper) CreateWorkspaceAPIKey(ctx context.Context, workspaceID, name string) (*cloud.WorkspaceApiKey, error) {
+func (h *CommandHelper) ListProjectAPIKeys(ctx context.Context, projectID string) ([]cloud.ProjectApiKey, error) {
+	c, err := h.newConsoleAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, res, err := c.ProjectAPI.ListProjectApiKeys(ctx, projectID).Execute()
+	if err != nil {
+		return nil, handleError("unable to list project API keys", res, err)
+	}
+	return keys, nil
+}
+
+// CreateWorkspaceAPIKey creates a workspace API key. Like for
+// CreateProjectAPIKey, an expiresIn of zero creates a key without expiry.
+func (h *CommandHelper) CreateWorkspaceAPIKey(ctx context.Context, workspaceID, name string, expiresIn time.Duration) (*cloud.WorkspaceApiKey, error) {
+	if expiresIn < 0 {
+		return nil, errors.New("API key expiry must not be negative")
+	}
+
 	c, err := h.newConsoleAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	key, res, err := c.WorkspaceAPI.CreateWorkspaceApiKey(ctx, workspaceID).CreateWorkspaceApiKeyBody(cloud.CreateWorkspaceApiKeyBody{Name: name}).Execute()
+	req := cloud.CreateWorkspaceApiKeyBody{Name: name}
+	if expiresIn > 0 {
+		expiresAt := time.Now().Add(expiresIn)
+		req.ExpiresAt = &expiresAt
+	}
+
+	key, res, err := c.WorkspaceAPI.CreateWorkspaceApiKey(ctx, workspaceID).CreateWorkspaceApiKeyBody(req).Execute()
 	if err != nil {
 		return nil, handleError("unable to create workspace API key", res, err)
 	}
@@ -73,11 +106,27 @@ func (h *CommandHelper) DeleteWorkspaceAPIKey(ctx context.Context, workspaceID,
 	}
 
 	if res, err := c.WorkspaceAPI.DeleteWorkspaceApiKey(ctx, workspaceID, keyID).Execute(); err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("unable to delete workspace API key: %w", ErrAPIKeyNotFound)
+		}
 		return handleError("unable to delete workspace API key", res, err)
 	}
 	return nil
 }
 
+func (h *CommandHelper) ListWorkspaceAPIKeys(ctx context.Context, workspaceID string) ([]cloud.WorkspaceApiKey, error) {
+	c, err := h.newConsoleAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, res, err := c.WorkspaceAPI.ListWorkspaceApiKeys(ctx, workspaceID).Execute()
+	if err != nil {
+		return nil, handleError("unable to list workspace API keys", res, err)
+	}
+	return keys, nil
+}
+
 // TemporaryAPIKey creates a short-lived project API key that is deleted via the
 // returned cleanup function. The key is additionally set to expire after
 // expiresIn so that it is removed automatically should the cleanup fail. An
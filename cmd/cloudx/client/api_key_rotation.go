@@ -0,0 +1,71 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// APIKeyRotation is an API key that was replaced by a successor, but is kept
+// until DeleteAfter so that its users can switch over.
+type APIKeyRotation struct {
+	ProjectID   string    `json:"project_id,omitempty"`
+	WorkspaceID string    `json:"workspace_id,omitempty"`
+	OldKeyID    string    `json:"old_key_id"`
+	NewKeyID    string    `json:"new_key_id"`
+	Name        string    `json:"name"`
+	DeleteAfter time.Time `json:"delete_after"`
+}
+
+// apiKeyRotationsPath returns the file with the pending rotations. Like the
+// project history, it is kept next to the config file.
+func (h *CommandHelper) apiKeyRotationsPath() string {
+	return h.configLocation + ".rotations.json"
+}
+
+// PendingAPIKeyRotations returns the rotations whose old key was not deleted
+// yet, in the order they were started.
+func (h *CommandHelper) PendingAPIKeyRotations() ([]APIKeyRotation, error) {
+	raw, err := os.ReadFile(h.apiKeyRotationsPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read the pending API key rotations: %w", err)
+	}
+
+	var rotations []APIKeyRotation
+	if err := json.Unmarshal(raw, &rotations); err != nil {
+		return nil, fmt.Errorf("unable to decode the pending API key rotations: %w", err)
+	}
+	return rotations, nil
+}
+
+// SetPendingAPIKeyRotations replaces the pending rotations.
+func (h *CommandHelper) SetPendingAPIKeyRotations(rotations []APIKeyRotation) error {
+	path := h.apiKeyRotationsPath()
+	if len(rotations) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("unable to write the pending API key rotations: %w", err)
+		}
+		return nil
+	}
+
+	raw, err := json.MarshalIndent(rotations, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, raw, 0600); err != nil {
+		return fmt.Errorf("unable to write the pending API key rotations: %w", err)
+	}
+	return nil
+}
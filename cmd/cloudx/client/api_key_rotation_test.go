@@ -0,0 +1,40 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingAPIKeyRotations(t *testing.T) {
+	h := &CommandHelper{configLocation: filepath.Join(t.TempDir(), "config.json")}
+
+	pending, err := h.PendingAPIKeyRotations()
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	rotations := []APIKeyRotation{
+		{ProjectID: "project", OldKeyID: "old", NewKeyID: "new", Name: "ci", DeleteAfter: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)},
+		{WorkspaceID: "workspace", OldKeyID: "old-ws", NewKeyID: "new-ws", Name: "ci", DeleteAfter: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)},
+	}
+	require.NoError(t, h.SetPendingAPIKeyRotations(rotations))
+	info, err := os.Stat(h.apiKeyRotationsPath())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	pending, err = h.PendingAPIKeyRotations()
+	require.NoError(t, err)
+	assert.Equal(t, rotations, pending)
+
+	require.NoError(t, h.SetPendingAPIKeyRotations(nil))
+	_, err = os.Stat(h.apiKeyRotationsPath())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	require.NoError(t, h.SetPendingAPIKeyRotations(nil), "removing twice is fine")
+}
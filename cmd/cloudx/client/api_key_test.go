@@ -0,0 +1,44 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteAPIKey(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/p/tokens/gone", "/workspaces/w/tokens/gone":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error":{"code":404,"message":"The requested resource could not be found"}}`)
+		case "/projects/p/tokens/key", "/workspaces/w/tokens/key":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	h := &CommandHelper{cloudConsoleAPIURL: &ts.URL, workspaceAPIKey: new("api-key"), configLocation: filepath.Join(t.TempDir(), "config.json"), VerboseErrWriter: io.Discard}
+	ctx := context.Background()
+
+	assert.NoError(t, h.DeleteProjectAPIKey(ctx, "p", "key"))
+	assert.NoError(t, h.DeleteWorkspaceAPIKey(ctx, "w", "key"))
+
+	assert.ErrorIs(t, h.DeleteProjectAPIKey(ctx, "p", "gone"), ErrAPIKeyNotFound)
+	assert.ErrorIs(t, h.DeleteWorkspaceAPIKey(ctx, "w", "gone"), ErrAPIKeyNotFound)
+
+	err := h.DeleteProjectAPIKey(ctx, "other", "key")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrAPIKeyNotFound)
+}
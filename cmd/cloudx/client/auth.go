@@ -4,6 +4,7 @@
 package client
 
 import (
+	"cmp"
 	"context"
 	stderrors "errors"
 	"fmt"
@@ -31,6 +32,9 @@ func (h *CommandHelper) checkAuthenticated(ctx context.Context) error {
 	if c.isAuthenticated {
 		return nil
 	}
+	if err := c.loadCredentials(); err != nil {
+		return err
+	}
 	if c.AccessToken == nil {
 		return ErrNotAuthenticated
 	}
@@ -99,11 +103,11 @@ func (h *CommandHelper) Authenticate(ctx context.Context) error {
 
 	config, err := h.getConfig()
 	if stderrors.Is(err, ErrNoConfig) {
-		config = &Config{
-			location: h.configLocation,
-		}
+		config = h.newConfig()
 	} else if err != nil {
 		return err
+	} else if err := config.loadCredentials(); err != nil {
+		return err
 	}
 
 	if config.AccessToken != nil {
@@ -120,26 +124,51 @@ func (h *CommandHelper) Authenticate(ctx context.Context) error {
 	return nil
 }
 
+// ClearConfig signs out of the selected profile. All other profiles are kept.
 func (h *CommandHelper) ClearConfig() error {
-	return h.UpdateConfig(&Config{
-		location: h.configLocation,
-	})
+	c := h.newConfig()
+	if err := c.deleteCredentials(); err != nil {
+		return err
+	}
+	return h.UpdateConfig(c)
 }
 
 func oauth2ClientConfig() *oauth2.Config {
+	return newOAuth2ClientConfig(CloudConsoleURL("project"))
+}
+
+func newOAuth2ClientConfig(authServer *url.URL) *oauth2.Config {
 	return &oauth2.Config{
 		ClientID: "ory-cli",
 		Endpoint: oauth2.Endpoint{
-			AuthURL:   urlx.AppendPaths(CloudConsoleURL("project"), "/oauth2/auth").String(),
-			TokenURL:  urlx.AppendPaths(CloudConsoleURL("project"), "/oauth2/token").String(),
-			AuthStyle: oauth2.AuthStyleInParams,
+			AuthURL:       urlx.AppendPaths(authServer, "/oauth2/auth").String(),
+			DeviceAuthURL: urlx.AppendPaths(authServer, "/oauth2/device/auth").String(),
+			TokenURL:      urlx.AppendPaths(authServer, "/oauth2/token").String(),
+			AuthStyle:     oauth2.AuthStyleInParams,
 		},
 	}
 }
 
+// authServerURL returns the URL of the authorization server the CLI signs in
+// to. It is only overridden in tests.
+func (h *CommandHelper) authServerURL() *url.URL {
+	if h.authServerURLOverride != nil {
+		return h.authServerURLOverride
+	}
+	return CloudConsoleURL("project")
+}
+
 func (h *CommandHelper) loginOAuth2(ctx context.Context) (*Config, error) {
-	client := oauth2ClientConfig()
-	token, err := h.oAuth2DanceWithServer(ctx, client)
+	client := newOAuth2ClientConfig(h.authServerURL())
+	var (
+		token *oauth2.Token
+		err   error
+	)
+	if h.deviceLogin {
+		token, err = h.oAuth2DeviceFlow(ctx, client)
+	} else {
+		token, err = h.oAuth2DanceWithServer(ctx, client)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -152,11 +181,9 @@ func (h *CommandHelper) loginOAuth2(ctx context.Context) (*Config, error) {
 		)
 	}
 
-	config := &Config{
-		AccessToken: token,
-		location:    h.configLocation,
-	}
-	userInfo, _, err := NewPublicOryProjectClient().OidcAPI.GetOidcUserInfo(context.WithValue(ctx, cloud.ContextOAuth2, config.TokenSource(ctx))).Execute()
+	config := h.newConfig()
+	config.AccessToken = token
+	userInfo, _, err := cloud.NewAPIClient(newSDKConfiguration(h.authServerURL().String())).OidcAPI.GetOidcUserInfo(context.WithValue(ctx, cloud.ContextOAuth2, config.TokenSource(ctx))).Execute()
 	if err != nil {
 		return nil, err
 	}
@@ -169,7 +196,11 @@ func (h *CommandHelper) loginOAuth2(ctx context.Context) (*Config, error) {
 		return nil, err
 	}
 
-	_, _ = fmt.Fprintln(h.VerboseErrWriter, "Successfully logged into Ory Network.")
+	if profile := config.Profile(); profile != DefaultProfile {
+		_, _ = fmt.Fprintf(h.VerboseErrWriter, "Successfully logged into Ory Network with profile %q.\n", profile)
+	} else {
+		_, _ = fmt.Fprintln(h.VerboseErrWriter, "Successfully logged into Ory Network.")
+	}
 	return config, nil
 }
 
@@ -260,6 +291,38 @@ If no browser opened, visit the below page to continue:
 	}
 }
 
+// oAuth2DeviceFlow signs in using the OAuth2 device authorization grant (RFC
+// 8628). It works without a browser on this machine and without the
+// authorization server being able to redirect to it, e.g. over SSH or in a dev
+// container.
+func (h *CommandHelper) oAuth2DeviceFlow(ctx context.Context, client *oauth2.Config) (*oauth2.Token, error) {
+	da, err := client.DeviceAuth(ctx,
+		oauth2.SetAuthURLParam("scope", "offline_access email profile"),
+		oauth2.SetAuthURLParam("audience", CloudConsoleURL("api").String()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the device login: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(h.VerboseErrWriter,
+		`To complete your login to Ory Network, visit the below page on any device:
+
+		%s
+
+and confirm that it shows the code: %s
+
+Waiting for the login to be approved...
+`, cmp.Or(da.VerificationURIComplete, da.VerificationURI), da.UserCode)
+
+	// DeviceAccessToken polls the token endpoint at the interval requested by
+	// the server until the login is approved, denied, or the code expires.
+	token, err := client.DeviceAccessToken(ctx, da)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete the device login: %w", err)
+	}
+	return token, nil
+}
+
 func redirectOK(w http.ResponseWriter, r *http.Request) {
 	location := CloudConsoleURL("")
 	location.Path = "/cli-auth-success"
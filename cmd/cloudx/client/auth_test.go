@@ -0,0 +1,102 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceLogin(t *testing.T) {
+	identityID := uuid.Must(uuid.NewV4())
+	var polls atomic.Int32
+
+	// The stand-in authorization server approves the login on the second poll.
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth2/device/auth", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "ory-cli", r.Form.Get("client_id"))
+		assert.Contains(t, r.Form.Get("scope"), "offline_access")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"device_code":               "device-code",
+			"user_code":                 "ABCD-EFGH",
+			"verification_uri":          "https://auth.example.com/device",
+			"verification_uri_complete": "https://auth.example.com/device?user_code=ABCD-EFGH",
+			"expires_in":                60,
+			"interval":                  1,
+		})
+	})
+	mux.HandleFunc("POST /oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:device_code", r.Form.Get("grant_type"))
+		assert.Equal(t, "device-code", r.Form.Get("device_code"))
+		w.Header().Set("Content-Type", "application/json")
+		if polls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": "authorization_pending"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "device-access-token",
+			"refresh_token": "device-refresh-token",
+			"token_type":    "bearer",
+			"expires_in":    3600,
+			"scope":         "offline_access email profile",
+		})
+	})
+	mux.HandleFunc("GET /userinfo", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer device-access-token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"sub":   identityID.String(),
+			"email": "dev@example.com",
+			"name":  "Dev",
+		})
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	authServer, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	stderr := new(bytes.Buffer)
+	location := filepath.Join(t.TempDir(), "config.json")
+
+	h, err := NewCommandHelper(context.Background(),
+		WithConfigLocation(location),
+		WithVerboseErrWriter(stderr),
+		WithDeviceLogin(true),
+		WithOpenBrowserHook(func(string) error {
+			t.Error("the device login must not open a browser")
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+	h.authServerURLOverride = authServer
+
+	require.NoError(t, h.Authenticate(context.Background()))
+	assert.EqualValues(t, 2, polls.Load())
+	assert.Contains(t, stderr.String(), "https://auth.example.com/device?user_code=ABCD-EFGH")
+	assert.Contains(t, stderr.String(), "ABCD-EFGH")
+	assert.Contains(t, stderr.String(), "You are now signed in as: dev@example.com")
+
+	c, err := NewCommandHelper(context.Background(), WithConfigLocation(location))
+	require.NoError(t, err)
+	conf, err := c.getConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "device-access-token", conf.AccessToken.AccessToken)
+	assert.Equal(t, "device-refresh-token", conf.AccessToken.RefreshToken)
+	assert.Equal(t, identityID, conf.IdentityTraits.ID)
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"os/user"
 	"strings"
@@ -41,17 +42,27 @@ var (
 
 type (
 	CommandHelper struct {
-		config *Config
+		config      *Config
+		configFile  *configFile
+		credentials CredentialStore
 		projectOverride, workspaceOverride,
 		projectAPIKey, workspaceAPIKey,
 		cloudConsoleAPIURL *string
-		projectID, workspaceID uuid.UUID
-		configLocation         string
-		noConfirm, isQuiet     bool
-		workspaceFromConfig    bool
-		VerboseErrWriter       io.Writer
-		Stdin                  *bufio.Reader
-		openBrowserHook        func(string) error
+		authServerURLOverride   *url.URL
+		projectID, workspaceID  uuid.UUID
+		configLocation, profile string
+		credentialStoreName     string
+		noConfirm, isQuiet      bool
+		deviceLogin             bool
+		ifMatch                 string
+		force                   bool
+		readProjects            map[string]*cloud.Project
+		workspaceFromConfig     bool
+		VerboseErrWriter        io.Writer
+		Stdin                   *bufio.Reader
+		// stdinFile is set if Stdin reads from a file, such as a terminal.
+		stdinFile       *os.File
+		openBrowserHook func(string) error
 	}
 	helperOptionsContextKey struct{}
 	CommandHelperOption     func(*CommandHelper)
@@ -71,6 +82,14 @@ func WithConfigLocation(location string) CommandHelperOption {
 	}
 }
 
+// WithProfile selects the named authentication profile of the configuration
+// file instead of the one selected with `ory use profile`.
+func WithProfile(profile string) CommandHelperOption {
+	return func(h *CommandHelper) {
+		h.profile = profile
+	}
+}
+
 func WithNoConfirm(noConfirm bool) CommandHelperOption {
 	return func(h *CommandHelper) {
 		h.noConfirm = noConfirm
@@ -92,6 +111,7 @@ func WithVerboseErrWriter(w io.Writer) CommandHelperOption {
 func WithStdin(r io.Reader) CommandHelperOption {
 	return func(h *CommandHelper) {
 		h.Stdin = bufio.NewReader(r)
+		h.stdinFile, _ = r.(*os.File)
 	}
 }
 
@@ -119,6 +139,30 @@ func WithWorkspaceAPIKey(apiKey string) CommandHelperOption {
 	}
 }
 
+// WithDeviceLogin makes the CLI sign in using the OAuth2 device authorization
+// grant instead of a browser redirect to a local callback server.
+func WithDeviceLogin(device bool) CommandHelperOption {
+	return func(h *CommandHelper) {
+		h.deviceLogin = device
+	}
+}
+
+// WithIfMatch makes writes to a project fail unless it is at the given
+// revision.
+func WithIfMatch(revision string) CommandHelperOption {
+	return func(h *CommandHelper) {
+		h.ifMatch = revision
+	}
+}
+
+// WithForce makes writes to a project skip the check whether it was changed
+// since it was read.
+func WithForce(force bool) CommandHelperOption {
+	return func(h *CommandHelper) {
+		h.force = force
+	}
+}
+
 func WithOpenBrowserHook(openBrowser func(string) error) CommandHelperOption {
 	return func(h *CommandHelper) {
 		h.openBrowserHook = openBrowser
@@ -149,6 +193,25 @@ func NewCobraCommandHelper(cmd *cobra.Command, opts ...CommandHelperOption) (*Co
 	if config := flagx.MustGetString(cmd, FlagConfig); config != "" {
 		defaultOpts = append(defaultOpts, WithConfigLocation(config))
 	}
+	// we explicitly ignore the error here, because the command might not support the profile flag (most do)
+	if profile, _ := cmd.Flags().GetString(FlagProfile); profile != "" {
+		defaultOpts = append(defaultOpts, WithProfile(profile))
+	}
+	// we explicitly ignore the error here, because only the auth commands support the device flag
+	if device, _ := cmd.Flags().GetBool(FlagDevice); device {
+		defaultOpts = append(defaultOpts, WithDeviceLogin(true))
+	}
+	// we explicitly ignore the error here, because only the auth commands support the credential store flag
+	if store, _ := cmd.Flags().GetString(FlagCredentialStore); store != "" {
+		defaultOpts = append(defaultOpts, WithCredentialStoreName(store))
+	}
+	// we explicitly ignore the errors here, because only the commands writing a project support these flags
+	if revision, _ := cmd.Flags().GetString(FlagIfMatch); revision != "" {
+		defaultOpts = append(defaultOpts, WithIfMatch(revision))
+	}
+	if force, _ := cmd.Flags().GetBool(FlagForce); force {
+		defaultOpts = append(defaultOpts, WithForce(true))
+	}
 	h, err := NewCommandHelper(cmd.Context(), append(defaultOpts, opts...)...)
 	if err != nil {
 		return nil, cmdx.PrintOpenAPIError(cmd, err)
@@ -167,6 +230,7 @@ func NewCommandHelper(ctx context.Context, opts ...CommandHelperOption) (*Comman
 		noConfirm:        false,
 		VerboseErrWriter: io.Discard,
 		Stdin:            bufio.NewReader(os.Stdin),
+		stdinFile:        os.Stdin,
 		openBrowserHook: func(uri string) error {
 			// we ignore the error in this case, as we also log the URL and we cannot recover in any way
 			_ = browser.OpenURL(uri)
@@ -194,6 +258,14 @@ func NewCommandHelper(ctx context.Context, opts ...CommandHelperOption) (*Comman
 		}
 	}
 
+	if h.profile == "" {
+		h.profile = os.Getenv(ProfileKey)
+	}
+
+	if h.profile != "" && !profileNamePattern.MatchString(h.profile) {
+		return nil, fmt.Errorf("invalid profile name %q, only letters, digits, '.', '_', and '-' are allowed", h.profile)
+	}
+
 	config, err := h.getOrCreateConfig()
 	if err != nil {
 		return nil, err
@@ -70,7 +70,7 @@ func TestCommandHelper(t *testing.T) {
 	defaultWorkspace, err := authenticated.CreateWorkspace(ctx, randx.MustString(6, randx.AlphaNum))
 	require.NoError(t, err)
 
-	defaultWorkspaceAPIKey, err := authenticated.CreateWorkspaceAPIKey(ctx, defaultWorkspace.Id, randx.MustString(6, randx.AlphaNum))
+	defaultWorkspaceAPIKey, err := authenticated.CreateWorkspaceAPIKey(ctx, defaultWorkspace.Id, randx.MustString(6, randx.AlphaNum), 0)
 	require.NoError(t, err)
 	require.NotNil(t, defaultWorkspaceAPIKey.Value)
 
@@ -170,7 +170,7 @@ func TestCommandHelper(t *testing.T) {
 		t.Run("list of workspace projects", func(t *testing.T) {
 			workspace, err := h.CreateWorkspace(ctx, t.Name())
 			require.NoError(t, err)
-			wsKey, err := authenticated.CreateWorkspaceAPIKey(ctx, workspace.Id, "test key")
+			wsKey, err := authenticated.CreateWorkspaceAPIKey(ctx, workspace.Id, "test key", 0)
 			require.NoError(t, err)
 			require.NotNil(t, wsKey.Value)
 
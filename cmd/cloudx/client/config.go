@@ -11,8 +11,11 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 
 	"golang.org/x/oauth2"
 
@@ -27,19 +30,31 @@ var (
 	ErrNoConfigQuiet    = errors.New("please authenticate the CLI or remove the `--quiet` flag")
 	ErrNotAuthenticated = errors.New("you are not authenticated, please run `ory auth` to authenticate")
 	ErrReauthenticate   = errors.New("your session or key has expired or has otherwise become invalid, re-authenticate to continue")
+	ErrProfileNotFound  = errors.New("profile not found")
 )
 
 const (
 	ConfigFileName = ".ory-cloud.json"
 	FlagConfig     = "config"
+	FlagProfile    = "profile"
 	ConfigPathKey  = "ORY_CONFIG_PATH"
-	ConfigVersion  = "v1"
+	ProfileKey     = "ORY_PROFILE"
+	ConfigVersion  = "v2"
+	DefaultProfile = "default"
 )
 
+// RegisterConfigFlag registers the flags selecting the configuration file and
+// the authentication profile within it. Every command reading the config file
+// registers it, which is what makes --profile available everywhere.
 func RegisterConfigFlag(f *pflag.FlagSet) {
 	f.StringP(FlagConfig, FlagConfig[:1], "", "Path to the Ory Network configuration file.")
+	f.String(FlagProfile, "", "The authentication profile to use. Defaults to the profile selected with `ory use profile`.")
 }
 
+// profileNamePattern restricts profile names, as they are also used as keys in
+// the credential stores.
+var profileNamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
 func getConfigPath() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -52,43 +67,121 @@ func getConfigPath() (string, error) {
 	), nil
 }
 
+// ConfigLocation returns the path of the configuration file. State the CLI
+// keeps locally is stored next to it.
+func (h *CommandHelper) ConfigLocation() string {
+	return h.configLocation
+}
+
 func (c *Config) writeUpdate() error {
-	c.Version = ConfigVersion
+	if c.file == nil {
+		c.file = new(configFile)
+	}
+	c.profile = cmp.Or(c.profile, DefaultProfile)
+	if err := c.storeCredentials(); err != nil {
+		return err
+	}
+	c.file.setProfile(c.profile, c)
+	return c.file.write(c.location)
+}
+
+func (f *configFile) write(location string) error {
+	f.Version = ConfigVersion
+
+	out := any(f)
+	if !isPlaintext(f.credentials) {
+		// The tokens live in the credential store, strip them from a copy so
+		// that the configs in use keep theirs.
+		stripped := *f
+		stripped.Profiles = make(map[string]*Config, len(f.Profiles))
+		for name, c := range f.Profiles {
+			if c != nil {
+				cc := *c
+				cc.AccessToken = nil
+				c = &cc
+			}
+			stripped.Profiles[name] = c
+		}
+		out = &stripped
+	}
 
-	f, err := os.OpenFile(c.location, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	file, err := os.OpenFile(location, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
-		return fmt.Errorf("unable to open file %q for writing: %w", c.location, err)
+		return fmt.Errorf("unable to open file %q for writing: %w", location, err)
 	}
-	defer f.Close()
+	defer file.Close()
 
-	if err := json.NewEncoder(f).Encode(c); err != nil {
-		return fmt.Errorf("unable to write configuration file %q: %w", c.location, err)
+	if err := json.NewEncoder(file).Encode(out); err != nil {
+		return fmt.Errorf("unable to write configuration file %q: %w", location, err)
 	}
 	return nil
 }
 
 func (h *CommandHelper) UpdateConfig(c *Config) error {
 	h.config = c
+	if c.file == nil {
+		c.file = new(configFile)
+	}
+	if err := h.initCredentials(c.file); err != nil {
+		return err
+	}
 	return c.writeUpdate()
 }
 
 func (h *CommandHelper) getOrCreateConfig() (*Config, error) {
 	c, err := h.getConfig()
 	if errors.Is(err, ErrNoConfig) {
-		return &Config{
-			location: h.configLocation,
-		}, nil
+		return h.newConfig(), nil
 	}
 	return c, err
 }
 
+// newConfig returns an empty configuration for the selected profile. All other
+// profiles already stored in the configuration file are kept.
+func (h *CommandHelper) newConfig() *Config {
+	if h.configFile == nil {
+		h.configFile = new(configFile)
+	}
+	f := h.configFile
+	return &Config{
+		location: h.configLocation,
+		profile:  h.profileName(f),
+		file:     f,
+	}
+}
+
+// profileName returns the profile to use: the one given by flag or
+// environment, otherwise the profile selected in the configuration file.
+func (h *CommandHelper) profileName(f *configFile) string {
+	return cmp.Or(h.profile, f.CurrentProfile, DefaultProfile)
+}
+
 func (h *CommandHelper) getConfig() (*Config, error) {
 	if h.config == nil {
-		c, err := readConfig(h.configLocation)
+		f, err := h.getConfigFile()
 		if err != nil {
 			return nil, err
 		}
-		switch c.Version {
+		name := h.profileName(f)
+		c, ok := f.Profiles[name]
+		if !ok || c == nil {
+			return nil, ErrNoConfig
+		}
+		c.location = h.configLocation
+		c.profile = name
+		c.file = f
+		h.config = c
+	}
+	return h.config, nil
+}
+
+func (h *CommandHelper) getConfigFile() (*configFile, error) {
+	if h.configFile == nil {
+		f, err := readConfig(h.configLocation)
+		if err != nil {
+			return nil, err
+		}
+		switch f.Version {
 		case "v0alpha0":
 			if h.projectAPIKey == nil && h.workspaceAPIKey == nil {
 				if h.isQuiet {
@@ -109,32 +202,100 @@ func (h *CommandHelper) getConfig() (*Config, error) {
 			fallthrough
 		default:
 			return nil, ErrNoConfig
+		case "v1":
+			// v1 stored a single session at the top level of the file. It
+			// becomes the default profile, and the file is written in the
+			// current format with the next update.
+			f.CurrentProfile = DefaultProfile
+			f.Profiles = map[string]*Config{DefaultProfile: f.v1}
 		case ConfigVersion:
 			// pass
 		}
-		h.config = c
+		if err := h.initCredentials(f); err != nil {
+			return nil, err
+		}
+		h.configFile = f
 	}
-	return h.config, nil
+	return h.configFile, nil
 }
 
-func readConfig(location string) (*Config, error) {
-	f, err := os.Open(location)
+func readConfig(location string) (*configFile, error) {
+	raw, err := os.ReadFile(location)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
 			return nil, ErrNoConfig
 		}
 		return nil, fmt.Errorf("unable to open ory config file %q: %w", location, err)
 	}
-	defer f.Close()
 
-	c := Config{
-		location: location,
-	}
-	if err := json.NewDecoder(f).Decode(&c); err != nil {
+	var f configFile
+	if err := json.Unmarshal(raw, &f); err != nil {
 		return nil, fmt.Errorf("unable to JSON decode the ory config file %q: %w", location, err)
 	}
+	if f.Version == "v1" {
+		f.v1 = new(Config)
+		if err := json.Unmarshal(raw, f.v1); err != nil {
+			return nil, fmt.Errorf("unable to JSON decode the ory config file %q: %w", location, err)
+		}
+	}
+
+	return &f, nil
+}
+
+// SelectProfile makes the named profile the one used when neither --profile
+// nor ORY_PROFILE is set. The profile must have been signed in to before.
+func (h *CommandHelper) SelectProfile(name string) error {
+	f, err := h.getConfigFile()
+	if errors.Is(err, ErrNoConfig) {
+		f = new(configFile)
+	} else if err != nil {
+		return err
+	}
+
+	if _, ok := f.Profiles[name]; !ok {
+		return fmt.Errorf("%w: %q, sign in with `ory auth login --profile %s` first", ErrProfileNotFound, name, name)
+	}
+	if f.CurrentProfile == name {
+		// nothing to do
+		return nil
+	}
 
-	return &c, nil
+	f.CurrentProfile = name
+	h.profile = name
+	h.config = nil
+	return f.write(h.configLocation)
+}
+
+// ProfileName returns the name of the authentication profile in use.
+func (h *CommandHelper) ProfileName() string {
+	if h.configFile == nil {
+		return cmp.Or(h.profile, DefaultProfile)
+	}
+	return h.profileName(h.configFile)
+}
+
+// ListProfiles returns all profiles stored in the configuration file, sorted
+// by name.
+func (h *CommandHelper) ListProfiles() ([]*Config, error) {
+	f, err := h.getConfigFile()
+	if errors.Is(err, ErrNoConfig) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]*Config, 0, len(f.Profiles))
+	for _, name := range slices.Sorted(maps.Keys(f.Profiles)) {
+		c := f.Profiles[name]
+		if c == nil {
+			continue
+		}
+		c.location = h.configLocation
+		c.profile = name
+		c.file = f
+		profiles = append(profiles, c)
+	}
+	return profiles, nil
 }
 
 func (h *CommandHelper) SelectWorkspace(id string) error {
@@ -179,8 +340,37 @@ func (h *CommandHelper) SelectProject(id string) error {
 	return h.UpdateConfig(conf)
 }
 
+// configFile is the content of the configuration file. It holds one Config
+// per named profile, so that switching between accounts does not require
+// signing out and in again.
+type configFile struct {
+	Version         string             `json:"version"`
+	CurrentProfile  string             `json:"current_profile"`
+	CredentialStore string             `json:"credential_store,omitempty"`
+	Profiles        map[string]*Config `json:"profiles"`
+
+	// v1 is the single session stored at the top level of v1 files.
+	v1 *Config
+	// credentials is the store holding the tokens of all profiles. If it is
+	// the plaintext store, the tokens are written to the file itself.
+	credentials CredentialStore
+}
+
+func (f *configFile) setProfile(name string, c *Config) {
+	if f.Profiles == nil {
+		f.Profiles = make(map[string]*Config)
+	}
+	f.Profiles[name] = c
+	if f.CurrentProfile == "" {
+		f.CurrentProfile = name
+	}
+}
+
+// Config is the session and the selections of one profile.
 type Config struct {
-	Version           string        `json:"version"`
+	// AccessToken is only written to the configuration file when the
+	// plaintext credential store is used. Otherwise, it is loaded from the
+	// credential store once a session is needed.
 	AccessToken       *oauth2.Token `json:"access_token"`
 	SelectedProject   uuid.UUID     `json:"selected_project"`
 	SelectedWorkspace uuid.UUID     `json:"selected_workspace"`
@@ -191,12 +381,25 @@ type Config struct {
 	isAuthenticated bool
 	// location is the path to the configuration file
 	location string
+	// profile is the name this config is stored under in the configuration file
+	profile string
+	// file is the configuration file holding this and all other profiles
+	file *configFile
+	// storedToken is the token as last read from or written to the credential
+	// store, so that it is only written again after a change.
+	storedToken       *oauth2.Token
+	credentialsLoaded bool
 }
 
 func (c *Config) ID() string {
 	return c.IdentityTraits.ID.String()
 }
 
+// Profile returns the name of the profile this config belongs to.
+func (c *Config) Profile() string {
+	return cmp.Or(c.profile, DefaultProfile)
+}
+
 func (*Config) Header() []string {
 	return []string{"ID", "EMAIL", "SELECTED PROJECT", "SELECTED WORKSPACE"}
 }
@@ -234,7 +437,7 @@ func (c *Config) TokenSource(ctx context.Context) oauth2.TokenSource {
 	}
 }
 
-// autoStoreRefreshedTokenSource is a token source that automatically stores the refreshed token in the config file.
+// autoStoreRefreshedTokenSource is a token source that automatically stores the refreshed token in the credential store.
 // Because it holds the context, it should not be re-used. Always create a new one using Config.TokenSource() with the current context.
 type autoStoreRefreshedTokenSource struct {
 	ctx context.Context
@@ -242,6 +445,12 @@ type autoStoreRefreshedTokenSource struct {
 }
 
 func (s *autoStoreRefreshedTokenSource) Token() (*oauth2.Token, error) {
+	if err := s.c.loadCredentials(); err != nil {
+		return nil, err
+	}
+	if s.c.AccessToken == nil {
+		return nil, ErrNotAuthenticated
+	}
 	newToken, err := oauth2ClientConfig().TokenSource(s.ctx, s.c.AccessToken).Token()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,586 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/oauth2"
+	"golang.org/x/term"
+)
+
+const (
+	FlagCredentialStore      = "credential-store"
+	CredentialStoreKey       = "ORY_CREDENTIAL_STORE"
+	CredentialsPassphraseKey = "ORY_CREDENTIALS_PASSPHRASE"
+
+	// CredentialStoreFile keeps the tokens in plaintext in the configuration file.
+	CredentialStoreFile = "file"
+	// CredentialStoreKeyring keeps the tokens in the operating system's keyring.
+	CredentialStoreKeyring = "keyring"
+	// CredentialStoreEncryptedFile keeps the tokens in a passphrase-encrypted file
+	// next to the configuration file.
+	CredentialStoreEncryptedFile = "encrypted-file"
+
+	keyringService = "ory-cli"
+)
+
+var ErrNoCredentials = errors.New("no credentials stored for this profile")
+
+func RegisterCredentialStoreFlag(f *pflag.FlagSet) {
+	f.String(FlagCredentialStore, "", fmt.Sprintf("Where to store the session tokens: %q (plaintext in the configuration file), %q (the operating system keyring), or %q (a passphrase-encrypted file). The choice is remembered, and existing tokens are moved over.", CredentialStoreFile, CredentialStoreKeyring, CredentialStoreEncryptedFile))
+}
+
+// CredentialStore keeps the OAuth2 token of each profile. The configuration
+// file only records which store is in use, so that the tokens do not have to be
+// written to it in plaintext.
+type CredentialStore interface {
+	// Name identifies the store in the configuration file.
+	Name() string
+	// Get returns the token stored for the profile, or ErrNoCredentials.
+	Get(profile string) (*oauth2.Token, error)
+	Set(profile string, token *oauth2.Token) error
+	// Delete removes the token of the profile. Deleting a token that does not
+	// exist is not an error.
+	Delete(profile string) error
+}
+
+// WithCredentialStore overrides the credential store configured by flag,
+// environment, or configuration file.
+func WithCredentialStore(store CredentialStore) CommandHelperOption {
+	return func(h *CommandHelper) {
+		h.credentials = store
+	}
+}
+
+// WithCredentialStoreName selects the credential store by name.
+func WithCredentialStoreName(name string) CommandHelperOption {
+	return func(h *CommandHelper) {
+		h.credentialStoreName = name
+	}
+}
+
+// credentialStore returns the store to use for the configuration file.
+func (h *CommandHelper) credentialStore(f *configFile) (CredentialStore, error) {
+	if h.credentials != nil {
+		return h.credentials, nil
+	}
+	return h.newCredentialStore(cmp.Or(h.credentialStoreName, os.Getenv(CredentialStoreKey), f.CredentialStore, CredentialStoreFile))
+}
+
+func (h *CommandHelper) newCredentialStore(name string) (CredentialStore, error) {
+	switch name {
+	case CredentialStoreFile:
+		return plaintextCredentialStore{}, nil
+	case CredentialStoreKeyring:
+		return newKeyringCredentialStore()
+	case CredentialStoreEncryptedFile:
+		return &encryptedFileCredentialStore{
+			path:       h.configLocation + ".credentials",
+			passphrase: h.readPassphrase,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown credential store %q, expected one of %q, %q, or %q", name, CredentialStoreFile, CredentialStoreKeyring, CredentialStoreEncryptedFile)
+	}
+}
+
+// readPassphrase asks for the passphrase of the credentials file. On a
+// terminal, the passphrase is not echoed and, if the file is about to be
+// created, asked for twice so that a typo does not lock the user out.
+func (h *CommandHelper) readPassphrase(create bool) (string, error) {
+	if p, ok := os.LookupEnv(CredentialsPassphraseKey); ok {
+		return p, nil
+	}
+	if h.isQuiet {
+		return "", fmt.Errorf("the credentials file is encrypted, set %s to unlock it when --quiet is set", CredentialsPassphraseKey)
+	}
+
+	prompt := "Enter the passphrase of the Ory CLI credentials file: "
+	if create {
+		prompt = "Choose a passphrase for the Ory CLI credentials file: "
+	}
+	p, err := h.readSecret(prompt)
+	if err != nil {
+		return "", err
+	}
+	if p == "" {
+		return "", errors.New("the passphrase must not be empty")
+	}
+
+	if create && h.stdinIsTerminal() {
+		confirmation, err := h.readSecret("Enter the passphrase again: ")
+		if err != nil {
+			return "", err
+		}
+		if confirmation != p {
+			return "", errors.New("the passphrases do not match")
+		}
+	}
+	return p, nil
+}
+
+func (h *CommandHelper) stdinIsTerminal() bool {
+	return h.stdinFile != nil && term.IsTerminal(int(h.stdinFile.Fd()))
+}
+
+// readSecret reads a line from stdin, without echoing it on a terminal.
+func (h *CommandHelper) readSecret(prompt string) (string, error) {
+	_, _ = fmt.Fprint(h.VerboseErrWriter, prompt)
+	if h.stdinIsTerminal() {
+		p, err := term.ReadPassword(int(h.stdinFile.Fd()))
+		_, _ = fmt.Fprintln(h.VerboseErrWriter)
+		if err != nil {
+			return "", fmt.Errorf("unable to read the passphrase from the terminal: %w", err)
+		}
+		return string(p), nil
+	}
+
+	p, err := h.Stdin.ReadString('\n')
+	if err != nil && p == "" {
+		return "", fmt.Errorf("unable to read the passphrase from stdin: %w", err)
+	}
+	return strings.TrimRight(p, "\r\n"), nil
+}
+
+// initCredentials resolves the credential store of the configuration file and
+// migrates the tokens to it if it changed.
+func (h *CommandHelper) initCredentials(f *configFile) error {
+	if f.credentials != nil {
+		return nil
+	}
+	store, err := h.credentialStore(f)
+	if err != nil {
+		return err
+	}
+	return h.migrateCredentials(f, store)
+}
+
+// migrateCredentials moves the tokens of all profiles to store if the
+// configuration file still references another store, including tokens kept in
+// plaintext in the configuration file itself.
+func (h *CommandHelper) migrateCredentials(f *configFile, store CredentialStore) error {
+	f.credentials = store
+	previous := cmp.Or(f.CredentialStore, CredentialStoreFile)
+	if previous == store.Name() {
+		f.CredentialStore = previous
+		return nil
+	}
+
+	previousStore, err := h.newCredentialStore(previous)
+	if err != nil {
+		return err
+	}
+	moved := make([]string, 0, len(f.Profiles))
+	for name, c := range f.Profiles {
+		if c == nil {
+			continue
+		}
+		token := c.AccessToken
+		if token == nil {
+			token, err = previousStore.Get(name)
+			if errors.Is(err, ErrNoCredentials) {
+				continue
+			} else if err != nil {
+				return fmt.Errorf("unable to read the credentials of profile %q from the %s credential store: %w", name, previous, err)
+			}
+		}
+		if err := store.Set(name, token); err != nil {
+			return fmt.Errorf("unable to move the credentials of profile %q to the %s credential store: %w", name, store.Name(), err)
+		}
+		c.AccessToken = token
+		moved = append(moved, name)
+	}
+
+	// Only forget the tokens in the previous store once the configuration file
+	// points to the new one, so that an interrupted migration loses nothing.
+	f.CredentialStore = store.Name()
+	if err := f.write(h.configLocation); err != nil {
+		return err
+	}
+	for _, name := range moved {
+		if err := previousStore.Delete(name); err != nil {
+			_, _ = fmt.Fprintf(h.VerboseErrWriter, "Unable to remove the credentials of profile %q from the %s credential store, please remove them manually: %s\n", name, previous, err)
+		}
+	}
+	if len(moved) > 0 {
+		_, _ = fmt.Fprintf(h.VerboseErrWriter, "Moved the credentials of %d profile(s) to the %s credential store.\n", len(moved), store.Name())
+	}
+	return nil
+}
+
+func isPlaintext(store CredentialStore) bool {
+	_, ok := store.(plaintextCredentialStore)
+	return store == nil || ok
+}
+
+// loadCredentials reads the token of the profile from the credential store.
+// It is loaded lazily, so that commands that do not need a session (for
+// example when an API key is set) never unlock the store.
+func (c *Config) loadCredentials() error {
+	if c.credentialsLoaded || c.file == nil || isPlaintext(c.file.credentials) {
+		return nil
+	}
+	token, err := c.file.credentials.Get(c.Profile())
+	if errors.Is(err, ErrNoCredentials) {
+		token = nil
+	} else if err != nil {
+		return fmt.Errorf("unable to read the credentials of profile %q: %w", c.Profile(), err)
+	}
+	c.AccessToken = token
+	c.storedToken = token
+	c.credentialsLoaded = true
+	return nil
+}
+
+// storeCredentials writes the token of the profile to the credential store if
+// it changed since it was loaded.
+func (c *Config) storeCredentials() error {
+	if c.file == nil || isPlaintext(c.file.credentials) || c.AccessToken == c.storedToken {
+		return nil
+	}
+	var err error
+	if c.AccessToken == nil {
+		err = c.file.credentials.Delete(c.Profile())
+	} else {
+		err = c.file.credentials.Set(c.Profile(), c.AccessToken)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to store the credentials of profile %q: %w", c.Profile(), err)
+	}
+	c.storedToken = c.AccessToken
+	c.credentialsLoaded = true
+	return nil
+}
+
+// deleteCredentials removes the token of the profile from the credential store.
+func (c *Config) deleteCredentials() error {
+	if c.file == nil || isPlaintext(c.file.credentials) {
+		return nil
+	}
+	if err := c.file.credentials.Delete(c.Profile()); err != nil {
+		return fmt.Errorf("unable to remove the credentials of profile %q: %w", c.Profile(), err)
+	}
+	c.AccessToken, c.storedToken = nil, nil
+	c.credentialsLoaded = true
+	return nil
+}
+
+// plaintextCredentialStore keeps the tokens in the configuration file, which is
+// how the Ory CLI always stored them.
+type plaintextCredentialStore struct{}
+
+func (plaintextCredentialStore) Name() string { return CredentialStoreFile }
+
+func (plaintextCredentialStore) Get(string) (*oauth2.Token, error) { return nil, ErrNoCredentials }
+
+func (plaintextCredentialStore) Set(string, *oauth2.Token) error { return nil }
+
+func (plaintextCredentialStore) Delete(string) error { return nil }
+
+// keyringCredentialStore keeps the tokens in the Secret Service (Linux, BSD) or
+// the login keychain (macOS). It talks to them through their command line
+// tools, which ship with the respective desktop environments.
+type keyringCredentialStore struct {
+	run func(stdin []byte, name string, args ...string) ([]byte, error)
+}
+
+func newKeyringCredentialStore() (*keyringCredentialStore, error) {
+	tool := "secret-tool"
+	switch runtime.GOOS {
+	case "darwin":
+		tool = "security"
+	case "windows":
+		return nil, fmt.Errorf("the %s credential store is not supported on Windows, use %q instead", CredentialStoreKeyring, CredentialStoreEncryptedFile)
+	}
+	if _, err := exec.LookPath(tool); err != nil {
+		return nil, fmt.Errorf("the %s credential store requires %q to be installed: %w", CredentialStoreKeyring, tool, err)
+	}
+	return &keyringCredentialStore{run: runCommand}, nil
+}
+
+// keyringToolError is a failed run of a keyring tool.
+type keyringToolError struct {
+	tool     string
+	exitCode int
+	stderr   string
+	err      error
+}
+
+func (e *keyringToolError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.tool, e.err, e.stderr)
+}
+
+func (e *keyringToolError) Unwrap() error { return e.err }
+
+func runCommand(stdin []byte, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		toolErr := &keyringToolError{tool: name, exitCode: -1, stderr: strings.TrimSpace(stderr.String()), err: err}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			toolErr.exitCode = exitErr.ExitCode()
+		}
+		return out, toolErr
+	}
+	return out, nil
+}
+
+func (*keyringCredentialStore) Name() string { return CredentialStoreKeyring }
+
+func (s *keyringCredentialStore) Get(profile string) (*oauth2.Token, error) {
+	var (
+		out []byte
+		err error
+	)
+	if runtime.GOOS == "darwin" {
+		out, err = s.run(nil, "security", "find-generic-password", "-s", keyringService, "-a", profile, "-w")
+	} else {
+		out, err = s.run(nil, "secret-tool", "lookup", "service", keyringService, "profile", profile)
+	}
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrNoCredentials
+		}
+		return nil, err
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	var token oauth2.Token
+	if err := json.Unmarshal(bytes.TrimSpace(out), &token); err != nil {
+		return nil, fmt.Errorf("unable to decode the token stored in the keyring: %w", err)
+	}
+	return &token, nil
+}
+
+func (s *keyringCredentialStore) Set(profile string, token *oauth2.Token) error {
+	raw, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	if runtime.GOOS == "darwin" {
+		// The secret is passed through an interactive session on stdin, and
+		// hex-encoded, so that it neither shows up in the process list nor
+		// needs quoting.
+		_, err = s.run(
+			fmt.Appendf(nil, "add-generic-password -U -s %s -a %s -X %s\n", keyringService, profile, hex.EncodeToString(raw)),
+			"security", "-i",
+		)
+		return err
+	}
+	_, err = s.run(raw, "secret-tool", "store", "--label", "Ory CLI ("+profile+")", "service", keyringService, "profile", profile)
+	return err
+}
+
+func (s *keyringCredentialStore) Delete(profile string) error {
+	if runtime.GOOS == "darwin" {
+		_, err := s.run(nil, "security", "delete-generic-password", "-s", keyringService, "-a", profile)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	// `secret-tool clear` succeeds if there is no such entry.
+	_, err := s.run(nil, "secret-tool", "clear", "service", keyringService, "profile", profile)
+	return err
+}
+
+// isNotFound reports whether the keyring tool failed because there was no
+// entry. `security` exits with status 44 in that case. `secret-tool lookup`
+// exits with status 1 without printing anything, whereas it explains all other
+// failures, such as a locked keyring or no D-Bus session, on stderr.
+func isNotFound(err error) bool {
+	var toolErr *keyringToolError
+	if !errors.As(err, &toolErr) {
+		return false
+	}
+	switch toolErr.tool {
+	case "security":
+		return toolErr.exitCode == 44
+	case "secret-tool":
+		return toolErr.exitCode == 1 && toolErr.stderr == ""
+	}
+	return false
+}
+
+// encryptedFileCredentialStore keeps the tokens in a file encrypted with
+// AES-256-GCM. The key is derived from a passphrase using PBKDF2.
+type encryptedFileCredentialStore struct {
+	path string
+	// passphrase returns the passphrase, create is set if the file does not
+	// exist yet.
+	passphrase func(create bool) (string, error)
+
+	// salt and key are cached so that the passphrase is asked for and the key
+	// derived at most once per command.
+	salt, key []byte
+}
+
+type encryptedCredentials struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+const pbkdf2Iterations = 600_000
+
+func (*encryptedFileCredentialStore) Name() string { return CredentialStoreEncryptedFile }
+
+func (s *encryptedFileCredentialStore) Get(profile string) (*oauth2.Token, error) {
+	tokens, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	token, ok := tokens[profile]
+	if !ok || token == nil {
+		return nil, ErrNoCredentials
+	}
+	return token, nil
+}
+
+func (s *encryptedFileCredentialStore) Set(profile string, token *oauth2.Token) error {
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+	tokens[profile] = token
+	return s.write(tokens)
+}
+
+func (s *encryptedFileCredentialStore) Delete(profile string) error {
+	if _, err := os.Stat(s.path); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[profile]; !ok {
+		return nil
+	}
+	delete(tokens, profile)
+	return s.write(tokens)
+}
+
+func (s *encryptedFileCredentialStore) gcm(salt []byte, create bool) (cipher.AEAD, error) {
+	if s.key == nil || !bytes.Equal(s.salt, salt) {
+		passphrase, err := s.passphrase(create)
+		if err != nil {
+			return nil, err
+		}
+		s.key, err = pbkdf2.Key(sha256.New, passphrase, salt, pbkdf2Iterations, 32)
+		if err != nil {
+			return nil, err
+		}
+		s.salt = salt
+	}
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *encryptedFileCredentialStore) read() (map[string]*oauth2.Token, error) {
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(map[string]*oauth2.Token), nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read the credentials file %q: %w", s.path, err)
+	}
+
+	var file encryptedCredentials
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("unable to decode the credentials file %q: %w", s.path, err)
+	}
+	aead, err := s.gcm(file.Salt, false)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		// Do not keep a key that cannot decrypt the file.
+		s.key = nil
+		return nil, fmt.Errorf("unable to decrypt the credentials file %q, is the passphrase correct?", s.path)
+	}
+
+	tokens := make(map[string]*oauth2.Token)
+	if err := json.Unmarshal(plaintext, &tokens); err != nil {
+		return nil, fmt.Errorf("unable to decode the credentials file %q: %w", s.path, err)
+	}
+	return tokens, nil
+}
+
+func (s *encryptedFileCredentialStore) write(tokens map[string]*oauth2.Token) error {
+	plaintext, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+
+	salt, create := s.salt, s.salt == nil
+	if create {
+		salt = make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+	}
+	aead, err := s.gcm(salt, create)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(encryptedCredentials{
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so that an interrupted write does not
+	// destroy the credentials of all profiles.
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to write the credentials file %q: %w", s.path, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("unable to write the credentials file %q: %w", s.path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to write the credentials file %q: %w", s.path, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("unable to write the credentials file %q: %w", s.path, err)
+	}
+	return nil
+}
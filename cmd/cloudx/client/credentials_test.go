@@ -0,0 +1,216 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"golang.org/x/oauth2"
+)
+
+type memoryCredentialStore map[string]*oauth2.Token
+
+func (memoryCredentialStore) Name() string { return "memory" }
+
+func (s memoryCredentialStore) Get(profile string) (*oauth2.Token, error) {
+	if t, ok := s[profile]; ok {
+		return t, nil
+	}
+	return nil, ErrNoCredentials
+}
+
+func (s memoryCredentialStore) Set(profile string, token *oauth2.Token) error {
+	s[profile] = token
+	return nil
+}
+
+func (s memoryCredentialStore) Delete(profile string) error {
+	delete(s, profile)
+	return nil
+}
+
+func TestEncryptedFileCredentialStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	newStore := func(passphrase string) *encryptedFileCredentialStore {
+		return &encryptedFileCredentialStore{path: path, passphrase: func(bool) (string, error) { return passphrase, nil }}
+	}
+
+	s := newStore("correct horse")
+	_, err := s.Get(DefaultProfile)
+	assert.ErrorIs(t, err, ErrNoCredentials)
+	require.NoError(t, s.Delete(DefaultProfile), "deleting from a missing file is not an error")
+
+	require.NoError(t, s.Set(DefaultProfile, &oauth2.Token{AccessToken: "default-token"}))
+	require.NoError(t, s.Set("acme", &oauth2.Token{AccessToken: "acme-token"}))
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "default-token")
+
+	token, err := newStore("correct horse").Get("acme")
+	require.NoError(t, err)
+	assert.Equal(t, "acme-token", token.AccessToken)
+
+	_, err = newStore("wrong").Get("acme")
+	assert.ErrorContains(t, err, "passphrase")
+
+	require.NoError(t, s.Delete("acme"))
+	_, err = newStore("correct horse").Get("acme")
+	assert.ErrorIs(t, err, ErrNoCredentials)
+}
+
+func TestEncryptedFileCredentialStoreCreation(t *testing.T) {
+	var creates []bool
+	s := &encryptedFileCredentialStore{path: filepath.Join(t.TempDir(), "credentials"), passphrase: func(create bool) (string, error) {
+		creates = append(creates, create)
+		return "correct horse", nil
+	}}
+	require.NoError(t, s.Set(DefaultProfile, &oauth2.Token{AccessToken: "default-token"}))
+	s.key, s.salt = nil, nil
+	_, err := s.Get(DefaultProfile)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, creates, "the passphrase is only confirmed when creating the file")
+
+	t.Run("case=piped passphrase", func(t *testing.T) {
+		h := &CommandHelper{VerboseErrWriter: io.Discard}
+		WithStdin(strings.NewReader("correct horse\n"))(h)
+		p, err := h.readPassphrase(true)
+		require.NoError(t, err)
+		assert.Equal(t, "correct horse", p, "the passphrase is read once if stdin is not a terminal")
+	})
+}
+
+func TestKeyringCredentialStoreErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		err     error
+		out     string
+		failure bool
+	}{
+		{name: "not found", err: &keyringToolError{tool: "secret-tool", exitCode: 1}},
+		{name: "locked keyring", err: &keyringToolError{tool: "secret-tool", exitCode: 1, stderr: "secret-tool: Cannot create an item in a locked collection"}, failure: true},
+		{name: "no D-Bus session", err: &keyringToolError{tool: "secret-tool", exitCode: 1, stderr: "secret-tool: Cannot autolaunch D-Bus without X11 $DISPLAY"}, failure: true},
+		{name: "missing tool", err: &keyringToolError{tool: "secret-tool", exitCode: -1, err: exec.ErrNotFound}, failure: true},
+	} {
+		t.Run("case="+tc.name, func(t *testing.T) {
+			if runtime.GOOS == "darwin" {
+				t.Skip("secret-tool is not used on macOS")
+			}
+			s := &keyringCredentialStore{run: func([]byte, string, ...string) ([]byte, error) { return []byte(tc.out), tc.err }}
+			_, err := s.Get(DefaultProfile)
+			if tc.failure {
+				assert.NotErrorIs(t, err, ErrNoCredentials, "keyring failures must not look like missing credentials")
+				assert.Error(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrNoCredentials)
+			}
+		})
+	}
+}
+
+func TestCredentialStoreMigration(t *testing.T) {
+	ctx := context.Background()
+	location := filepath.Join(t.TempDir(), "config.json")
+	token := &oauth2.Token{AccessToken: "default-token", Expiry: time.Now().Add(time.Hour)}
+
+	plain, err := NewCommandHelper(ctx, WithConfigLocation(location))
+	require.NoError(t, err)
+	c, err := plain.getOrCreateConfig()
+	require.NoError(t, err)
+	c.AccessToken = token
+	require.NoError(t, plain.UpdateConfig(c))
+
+	raw, err := os.ReadFile(location)
+	require.NoError(t, err)
+	assert.Equal(t, "default-token", gjson.GetBytes(raw, "profiles.default.access_token.access_token").String())
+
+	store := memoryCredentialStore{}
+	h, err := NewCommandHelper(ctx, WithConfigLocation(location), WithCredentialStore(store))
+	require.NoError(t, err)
+
+	raw, err = os.ReadFile(location)
+	require.NoError(t, err)
+	assert.False(t, gjson.GetBytes(raw, "profiles.default.access_token.access_token").Exists(), "%s", raw)
+	assert.Equal(t, "memory", gjson.GetBytes(raw, "credential_store").String())
+	require.Contains(t, store, DefaultProfile)
+	assert.Equal(t, "default-token", store[DefaultProfile].AccessToken)
+
+	require.NoError(t, h.checkAuthenticated(ctx))
+	c, err = h.getConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "default-token", c.AccessToken.AccessToken)
+
+	t.Run("case=updates do not write the token to the file", func(t *testing.T) {
+		require.NoError(t, h.SelectWorkspace("d1f3c5a6-5b1a-4b8e-9f7a-2a0c8f1e6b3d"))
+		raw, err := os.ReadFile(location)
+		require.NoError(t, err)
+		assert.False(t, gjson.GetBytes(raw, "profiles.default.access_token.access_token").Exists(), "%s", raw)
+	})
+
+	t.Run("case=signing out removes the token from the store", func(t *testing.T) {
+		h, err := NewCommandHelper(ctx, WithConfigLocation(location), WithCredentialStore(store))
+		require.NoError(t, err)
+		require.NoError(t, h.ClearConfig())
+		assert.NotContains(t, store, DefaultProfile)
+	})
+}
+
+func TestCredentialStoreMigrationToEncryptedFile(t *testing.T) {
+	ctx := context.Background()
+	location := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv(CredentialsPassphraseKey, "correct horse")
+
+	plain, err := NewCommandHelper(ctx, WithConfigLocation(location))
+	require.NoError(t, err)
+	c, err := plain.getOrCreateConfig()
+	require.NoError(t, err)
+	c.AccessToken = &oauth2.Token{AccessToken: "default-token"}
+	require.NoError(t, plain.UpdateConfig(c))
+
+	_, err = NewCommandHelper(ctx, WithConfigLocation(location), WithCredentialStoreName(CredentialStoreEncryptedFile))
+	require.NoError(t, err)
+	raw, err := os.ReadFile(location)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "default-token")
+	assert.FileExists(t, location+".credentials")
+
+	t.Run("case=the choice is remembered", func(t *testing.T) {
+		h, err := NewCommandHelper(ctx, WithConfigLocation(location))
+		require.NoError(t, err)
+		c, err := h.getConfig()
+		require.NoError(t, err)
+		require.NoError(t, c.loadCredentials())
+		assert.Equal(t, "default-token", c.AccessToken.AccessToken)
+	})
+
+	t.Run("case=moves the tokens back to the configuration file", func(t *testing.T) {
+		_, err := NewCommandHelper(ctx, WithConfigLocation(location), WithCredentialStoreName(CredentialStoreFile))
+		require.NoError(t, err)
+		raw, err := os.ReadFile(location)
+		require.NoError(t, err)
+		assert.Equal(t, "default-token", gjson.GetBytes(raw, "profiles.default.access_token.access_token").String())
+
+		_, err = (&encryptedFileCredentialStore{
+			path:       location + ".credentials",
+			passphrase: func(bool) (string, error) { return "correct horse", nil },
+		}).Get(DefaultProfile)
+		assert.ErrorIs(t, err, ErrNoCredentials)
+	})
+
+	t.Run("case=rejects unknown stores", func(t *testing.T) {
+		_, err := NewCommandHelper(ctx, WithConfigLocation(location), WithCredentialStoreName("vault"))
+		assert.ErrorContains(t, err, "unknown credential store")
+	})
+}
@@ -11,6 +11,9 @@ const (
 	FlagWorkspace = "workspace"
 	FlagProject   = "project"
 	FlagYes       = "yes"
+	FlagDevice    = "device"
+	FlagIfMatch   = "if-match"
+	FlagForce     = "force"
 )
 
 func RegisterWorkspaceFlag(f *pflag.FlagSet) {
@@ -24,3 +27,14 @@ func RegisterProjectFlag(f *pflag.FlagSet) {
 func RegisterYesFlag(f *pflag.FlagSet) {
 	f.BoolP(FlagYes, FlagYes[:1], false, "Confirm all dialogs with yes.")
 }
+
+func RegisterDeviceFlag(f *pflag.FlagSet) {
+	f.Bool(FlagDevice, false, "Sign in on another device using a verification code, for machines without a browser such as SSH sessions or dev containers.")
+}
+
+// RegisterIfMatchFlags registers the flags controlling the detection of
+// concurrent changes to a project.
+func RegisterIfMatchFlags(f *pflag.FlagSet) {
+	f.String(FlagIfMatch, "", "Only write the project if it is at this revision (see `revision_id` in `ory get project --format json`).")
+	f.Bool(FlagForce, false, "Write the project even if it was changed since the CLI last read it, for example using `ory get project`.")
+}
@@ -15,25 +15,53 @@ import (
 	"github.com/ory/x/stringsx"
 )
 
+// ProjectConfigSource is a file the project configuration is read from. It is
+// kept along with the parsed configuration, so that problems can be traced
+// back to the line in the file causing them.
+type ProjectConfigSource struct {
+	// Name is the location of the file as given by the user.
+	Name string
+	// Prefix is the JSON pointer the file's contents are placed at in the
+	// project configuration, e.g. `/services/identity/config`.
+	Prefix string
+	// Content is the file as read, in JSON or YAML.
+	Content []byte
+	// Config is the file's contents converted to JSON.
+	Config json.RawMessage
+}
+
 // ReadAndParseFiles reads and parses JSON/YAML files from the given sources.
 func ReadAndParseFiles(files []string) ([]json.RawMessage, error) {
+	sources, err := ReadProjectConfigSources(files, "")
+	if err != nil {
+		return nil, err
+	}
 	var fileContents []json.RawMessage
+	for _, s := range sources {
+		fileContents = append(fileContents, s.Config)
+	}
+	return fileContents, nil
+}
+
+// ReadProjectConfigSources reads and parses JSON/YAML files from the given
+// sources, which are placed at prefix in the project configuration.
+func ReadProjectConfigSources(files []string, prefix string) ([]ProjectConfigSource, error) {
+	sources := make([]ProjectConfigSource, 0, len(files))
 	for _, source := range files {
-		config, err := readAndParseFile(source)
+		contents, err := osx.ReadFileFromAllSources(source, osx.WithEnabledBase64Loader(), osx.WithEnabledHTTPLoader(), osx.WithEnabledFileLoader())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %q: %w", source, err)
+		}
+		config, err := parseFile(source, contents)
 		if err != nil {
 			return nil, err
 		}
-		fileContents = append(fileContents, config)
+		sources = append(sources, ProjectConfigSource{Name: source, Prefix: prefix, Content: contents, Config: config})
 	}
-	return fileContents, nil
+	return sources, nil
 }
 
-func readAndParseFile(source string) (json.RawMessage, error) {
-	contents, err := osx.ReadFileFromAllSources(source, osx.WithEnabledBase64Loader(), osx.WithEnabledHTTPLoader(), osx.WithEnabledFileLoader())
-	if err != nil {
-		return nil, fmt.Errorf("failed to read file %q: %w", source, err)
-	}
-
+func parseFile(source string, contents []byte) (json.RawMessage, error) {
 	switch f := stringsx.SwitchExact(filepath.Ext(source)); {
 	case f.AddCase(".yaml"), f.AddCase(".yml"):
 		var config json.RawMessage
@@ -9,18 +9,27 @@ import (
 	cloud "github.com/ory/client-go"
 )
 
+// ListOrganizations lists all organizations of the project, following the
+// pages of the API.
 func (h *CommandHelper) ListOrganizations(ctx context.Context, projectID string) (*cloud.ListOrganizationsResponse, error) {
 	c, err := h.newConsoleAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	organizations, res, err := c.ProjectAPI.ListOrganizations(ctx, projectID).Execute()
-	if err != nil {
-		return nil, handleError("unable to list organizations", res, err)
+	organizations := &cloud.ListOrganizationsResponse{Organizations: []cloud.Organization{}}
+	req := c.ProjectAPI.ListOrganizations(ctx, projectID)
+	for {
+		page, res, err := req.Execute()
+		if err != nil {
+			return nil, handleError("unable to list organizations", res, err)
+		}
+		organizations.Organizations = append(organizations.Organizations, page.Organizations...)
+		if !page.HasNextPage || page.NextPageToken == "" {
+			return organizations, nil
+		}
+		req = req.PageToken(page.NextPageToken)
 	}
-
-	return organizations, nil
 }
 
 func (h *CommandHelper) CreateOrganization(ctx context.Context, projectID string, body cloud.OrganizationBody) (*cloud.Organization, error) {
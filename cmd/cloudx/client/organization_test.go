@@ -0,0 +1,61 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloud "github.com/ory/client-go"
+)
+
+func TestListOrganizations(t *testing.T) {
+	const projectID = "ecaaa3cb-0730-4ee8-a6df-9553cdfeef89"
+
+	var tokens []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/"+projectID+"/organizations" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		token := r.URL.Query().Get("page_token")
+		tokens = append(tokens, token)
+
+		page := cloud.ListOrganizationsResponse{Organizations: []cloud.Organization{}}
+		switch token {
+		case "":
+			page.HasNextPage, page.NextPageToken = true, "page-2"
+		case "page-2":
+			page.HasNextPage, page.NextPageToken = true, "page-3"
+		}
+		for i := range 2 {
+			id := fmt.Sprintf("%s-%d", token, i)
+			page.Organizations = append(page.Organizations, cloud.Organization{Id: id, Label: id, Domains: []string{}})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(ts.Close)
+
+	h := &CommandHelper{cloudConsoleAPIURL: &ts.URL, workspaceAPIKey: new("api-key"), configLocation: filepath.Join(t.TempDir(), "config.json"), VerboseErrWriter: io.Discard}
+	organizations, err := h.ListOrganizations(context.Background(), projectID)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"", "page-2", "page-3"}, tokens)
+	ids := make([]string, len(organizations.Organizations))
+	for i, o := range organizations.Organizations {
+		ids[i] = o.Id
+	}
+	assert.Equal(t, []string{"-0", "-1", "page-2-0", "page-2-1", "page-3-0", "page-3-1"}, ids)
+	assert.False(t, organizations.HasNextPage)
+}
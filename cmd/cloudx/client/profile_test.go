@@ -0,0 +1,118 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"golang.org/x/oauth2"
+)
+
+func TestProfiles(t *testing.T) {
+	ctx := context.Background()
+	project := uuid.Must(uuid.NewV4())
+
+	newHelper := func(t *testing.T, location string, opts ...CommandHelperOption) *CommandHelper {
+		h, err := NewCommandHelper(ctx, append([]CommandHelperOption{WithConfigLocation(location)}, opts...)...)
+		require.NoError(t, err)
+		return h
+	}
+
+	t.Run("case=migrates a v1 config to the default profile", func(t *testing.T) {
+		location := filepath.Join(t.TempDir(), "config.json")
+		require.NoError(t, os.WriteFile(location, []byte(`{
+  "version": "v1",
+  "access_token": {"access_token": "v1-token"},
+  "selected_project": "`+project.String()+`"
+}`), 0600))
+
+		h := newHelper(t, location)
+		c, err := h.getConfig()
+		require.NoError(t, err)
+		assert.Equal(t, DefaultProfile, c.Profile())
+		assert.Equal(t, "v1-token", c.AccessToken.AccessToken)
+		assert.Equal(t, project, c.SelectedProject)
+
+		require.NoError(t, h.SelectWorkspace(uuid.Must(uuid.NewV4()).String()))
+		raw, err := os.ReadFile(location)
+		require.NoError(t, err)
+		assert.Equal(t, ConfigVersion, gjson.GetBytes(raw, "version").String())
+		assert.Equal(t, "v1-token", gjson.GetBytes(raw, "profiles.default.access_token.access_token").String())
+		assert.False(t, gjson.GetBytes(raw, "access_token").Exists(), "the v1 layout must not be written back")
+	})
+
+	t.Run("case=profiles are stored side by side", func(t *testing.T) {
+		location := filepath.Join(t.TempDir(), "config.json")
+
+		personal := newHelper(t, location)
+		require.NoError(t, personal.SelectProject(project.String()))
+
+		acme := newHelper(t, location, WithProfile("acme"))
+		c, err := acme.getOrCreateConfig()
+		require.NoError(t, err)
+		assert.Equal(t, uuid.Nil, c.SelectedProject, "a new profile must not inherit selections")
+		c.AccessToken = &oauth2.Token{AccessToken: "acme-token"}
+		require.NoError(t, acme.UpdateConfig(c))
+
+		profiles, err := newHelper(t, location).ListProfiles()
+		require.NoError(t, err)
+		require.Len(t, profiles, 2)
+		assert.Equal(t, "acme", profiles[0].Profile())
+		assert.Equal(t, "acme-token", profiles[0].AccessToken.AccessToken)
+		assert.Equal(t, DefaultProfile, profiles[1].Profile())
+		assert.Equal(t, project, profiles[1].SelectedProject)
+
+		t.Run("signing out keeps the other profiles", func(t *testing.T) {
+			require.NoError(t, newHelper(t, location, WithProfile("acme")).ClearConfig())
+
+			c, err := newHelper(t, location).getConfig()
+			require.NoError(t, err)
+			assert.Equal(t, project, c.SelectedProject)
+		})
+	})
+
+	t.Run("case=selects the profile used by default", func(t *testing.T) {
+		location := filepath.Join(t.TempDir(), "config.json")
+		raw, err := json.Marshal(map[string]any{
+			"version":         ConfigVersion,
+			"current_profile": DefaultProfile,
+			"profiles": map[string]any{
+				DefaultProfile: map[string]any{"access_token": map[string]any{"access_token": "default-token"}},
+				"acme":         map[string]any{"access_token": map[string]any{"access_token": "acme-token"}},
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(location, raw, 0600))
+
+		assert.ErrorIs(t, newHelper(t, location).SelectProfile("unknown"), ErrProfileNotFound)
+		require.NoError(t, newHelper(t, location).SelectProfile("acme"))
+
+		h := newHelper(t, location)
+		assert.Equal(t, "acme", h.ProfileName())
+		c, err := h.getConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "acme-token", c.AccessToken.AccessToken)
+
+		t.Run("the flag takes precedence", func(t *testing.T) {
+			c, err := newHelper(t, location, WithProfile(DefaultProfile)).getConfig()
+			require.NoError(t, err)
+			assert.Equal(t, "default-token", c.AccessToken.AccessToken)
+		})
+
+		t.Run("the environment takes precedence", func(t *testing.T) {
+			t.Setenv(ProfileKey, DefaultProfile)
+			c, err := newHelper(t, location).getConfig()
+			require.NoError(t, err)
+			assert.Equal(t, "default-token", c.AccessToken.AccessToken)
+		})
+	})
+}
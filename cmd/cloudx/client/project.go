@@ -8,6 +8,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"strings"
 	"time"
@@ -110,6 +111,7 @@ func (h *CommandHelper) GetProject(ctx context.Context, idOrSlug string, workspa
 	if err != nil {
 		return nil, handleError("unable to get project", res, err)
 	}
+	h.rememberProject(project)
 
 	return project, nil
 }
@@ -180,6 +182,29 @@ func (h *CommandHelper) PatchProject(ctx context.Context, id string, raw []json.
 		return nil, err
 	}
 
+	patches, err := projectPatches(raw, add, replace, del)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := h.checkProjectRevision(ctx, id, func(*client.Project) []ProjectChange { return patchChanges(patches) })
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := c.ProjectAPI.PatchProject(ctx, id).JsonPatch(patches).Execute()
+	if err != nil {
+		return nil, err
+	}
+	h.rememberProject(&res.Project)
+	h.recordProjectHistory(ctx, before)
+
+	return res, nil
+}
+
+// projectPatches combines the JSON Patch documents and the operations given as
+// flags into a single patch.
+func projectPatches(raw []json.RawMessage, add, replace, del []string) ([]client.JsonPatch, error) {
 	var patches []client.JsonPatch
 	for _, r := range raw {
 		config, err := jsonx.EmbedSources(r, jsonx.WithIgnoreKeys("$id", "$schema"), jsonx.WithOnlySchemes("file"))
@@ -212,12 +237,7 @@ func (h *CommandHelper) PatchProject(ctx context.Context, id string, raw []json.
 		patches = append(patches, client.JsonPatch{Op: "remove", Path: del})
 	}
 
-	res, _, err := c.ProjectAPI.PatchProject(ctx, id).JsonPatch(patches).Execute()
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
+	return patches, nil
 }
 
 func (h *CommandHelper) UpdateProject(ctx context.Context, id string, name string, configs []json.RawMessage) (*client.SuccessfulProjectUpdate, error) {
@@ -226,34 +246,13 @@ func (h *CommandHelper) UpdateProject(ctx context.Context, id string, name strin
 		return nil, err
 	}
 
-	for k := range configs {
-		config, err := jsonx.EmbedSources(
-			configs[k],
-			jsonx.WithIgnoreKeys(
-				"$id",
-				"$schema",
-			),
-			jsonx.WithOnlySchemes(
-				"file",
-			),
-		)
-		if err != nil {
-			return nil, err
-		}
-		configs[k] = config
+	interim, err := mergeProjectConfigs(configs)
+	if err != nil {
+		return nil, err
 	}
 
-	interim := make(map[string]interface{})
-	for _, config := range configs {
-		var decoded map[string]interface{}
-		if err := json.Unmarshal(config, &decoded); err != nil {
-			return nil, errors.WithStack(err)
-		}
-
-		if err := mergo.Merge(&interim, decoded, mergo.WithAppendSlice, mergo.WithOverride); err != nil {
-			return nil, errors.WithStack(err)
-		}
-	}
+	// Keep what was requested, to describe it in case of a conflict.
+	desired := maps.Clone(interim)
 
 	_, corsAdminFound := interim["cors_admin"]
 	if !corsAdminFound {
@@ -287,15 +286,24 @@ func (h *CommandHelper) UpdateProject(ctx context.Context, id string, name strin
 		payload.Name = name
 	}
 
-	// If either of the CORS keys is not set after the merge, we need to fetch it from the server
-	// If the name is not set, and it was not provided, we need to fetch it from the server
+	// The project is fetched right before it is written, to detect whether it
+	// changed since it was read.
+	res, err := h.checkProjectRevision(ctx, id, func(base *client.Project) []ProjectChange {
+		plan, err := planProject(base, desired)
+		if err != nil {
+			return nil
+		}
+		return plan.Changes
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// If either of the CORS keys is not set after the merge, we need to take it from the server
+	// If the name is not set, and it was not provided, we need to take it from the server
 	needsBackfill := !corsAdminFound || !corsPublicFound || payload.Name == "" || !orgsFound
 
 	if needsBackfill {
-		res, _, err := c.ProjectAPI.GetProject(ctx, id).Execute()
-		if err != nil {
-			return nil, errors.WithStack(err)
-		}
 		if payload.Name == "" {
 			payload.Name = res.Name
 		}
@@ -310,12 +318,48 @@ func (h *CommandHelper) UpdateProject(ctx context.Context, id string, name strin
 		}
 	}
 
-	res, _, err := c.ProjectAPI.SetProject(ctx, id).SetProject(payload).Execute()
+	updated, _, err := c.ProjectAPI.SetProject(ctx, id).SetProject(payload).Execute()
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	h.rememberProject(&updated.Project)
+	h.recordProjectHistory(ctx, res)
 
-	return res, nil
+	return updated, nil
+}
+
+// mergeProjectConfigs embeds the file sources referenced in the configs and
+// merges them in order, later configs taking precedence.
+func mergeProjectConfigs(configs []json.RawMessage) (map[string]interface{}, error) {
+	for k := range configs {
+		config, err := jsonx.EmbedSources(
+			configs[k],
+			jsonx.WithIgnoreKeys(
+				"$id",
+				"$schema",
+			),
+			jsonx.WithOnlySchemes(
+				"file",
+			),
+		)
+		if err != nil {
+			return nil, err
+		}
+		configs[k] = config
+	}
+
+	interim := make(map[string]interface{})
+	for _, config := range configs {
+		var decoded map[string]interface{}
+		if err := json.Unmarshal(config, &decoded); err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		if err := mergo.Merge(&interim, decoded, mergo.WithAppendSlice, mergo.WithOverride); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return interim, nil
 }
 
 func (h *CommandHelper) PrintUpdateProjectWarnings(p *client.SuccessfulProjectUpdate) error {
@@ -0,0 +1,186 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ory/client-go"
+)
+
+// ProjectConflictError is returned when a project was changed by someone else
+// between reading and writing it.
+type ProjectConflictError struct {
+	ProjectID string `json:"project_id"`
+	// Expected is the revision the change was based on, Actual the project's
+	// current revision.
+	Expected string `json:"expected_revision"`
+	Actual   string `json:"actual_revision"`
+	// Theirs are the changes made by someone else since the project was
+	// read. They are unknown if only the expected revision is known.
+	Theirs []ProjectChange `json:"theirs,omitempty"`
+	// Ours are the changes that were about to be written.
+	Ours []ProjectChange `json:"ours,omitempty"`
+}
+
+func (e *ProjectConflictError) Error() string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "project %s was changed since it was read: expected revision %s, but the project is at revision %s\n", e.ProjectID, e.Expected, e.Actual)
+
+	if len(e.Theirs) > 0 {
+		_, _ = fmt.Fprintln(&b, "\nChanged by someone else:")
+		writeProjectChanges(&b, e.Theirs)
+	}
+	if len(e.Ours) > 0 {
+		_, _ = fmt.Fprintln(&b, "\nYour changes:")
+		writeProjectChanges(&b, e.Ours)
+	}
+	if conflicts := e.Conflicts(); len(conflicts) > 0 {
+		_, _ = fmt.Fprintln(&b, "\nChanged by both:")
+		for _, c := range conflicts {
+			_, _ = fmt.Fprintf(&b, "  ! %s\n", c)
+		}
+	}
+
+	_, _ = fmt.Fprintf(&b, "\nRead the project again, for example using `ory get project %s`, to base your changes on its current configuration, or use --force to overwrite it.", e.ProjectID)
+	return b.String()
+}
+
+// Conflicts returns the paths changed by both sides, where either change
+// replaces the other.
+func (e *ProjectConflictError) Conflicts() []string {
+	var conflicts []string
+	for _, ours := range e.Ours {
+		for _, theirs := range e.Theirs {
+			if overlappingJSONPointers(ours.Path, theirs.Path) {
+				conflicts = append(conflicts, ours.Path)
+				break
+			}
+		}
+	}
+	return conflicts
+}
+
+func overlappingJSONPointers(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
+
+// projectBasePath returns the file with the project as the CLI last read or
+// wrote it. Like the history, it is kept next to the config file.
+func (h *CommandHelper) projectBasePath(projectID string) string {
+	return filepath.Join(h.configLocation+".base", projectID+".json")
+}
+
+// rememberProject records the project as read, so that later writes, also by
+// other commands, can detect whether it was changed in the meantime.
+func (h *CommandHelper) rememberProject(p *client.Project) {
+	if h.readProjects == nil {
+		h.readProjects = make(map[string]*client.Project)
+	}
+	h.readProjects[p.Id] = p
+	if err := h.writeProjectBase(p); err != nil {
+		_, _ = fmt.Fprintf(h.VerboseErrWriter, "Unable to record revision %s of project %s: %s\n", p.RevisionId, p.Id, err)
+	}
+}
+
+func (h *CommandHelper) writeProjectBase(p *client.Project) error {
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	path := h.projectBasePath(p.Id)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	// The configuration contains secrets, such as SMTP credentials.
+	return os.WriteFile(path, raw, 0600)
+}
+
+// projectBase returns the project as last read or written by the CLI, or nil
+// if it was not.
+func (h *CommandHelper) projectBase(projectID string) *client.Project {
+	if p, ok := h.readProjects[projectID]; ok {
+		return p
+	}
+	raw, err := os.ReadFile(h.projectBasePath(projectID))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			_, _ = fmt.Fprintf(h.VerboseErrWriter, "Unable to read the recorded revision of project %s: %s\n", projectID, err)
+		}
+		return nil
+	}
+	var p client.Project
+	if err := json.Unmarshal(raw, &p); err != nil {
+		_, _ = fmt.Fprintf(h.VerboseErrWriter, "Unable to decode the recorded revision of project %s: %s\n", projectID, err)
+		return nil
+	}
+	return &p
+}
+
+// checkProjectRevision fetches the project right before it is written and
+// makes sure it is still at the revision the change is based on. That is the
+// revision set with --if-match, or otherwise the one the CLI last read or
+// wrote, for example using `ory get project`. The ours function returns the
+// changes about to be written relative to the given project, for the error
+// message. It returns the fetched project.
+func (h *CommandHelper) checkProjectRevision(ctx context.Context, id string, ours func(base *client.Project) []ProjectChange) (*client.Project, error) {
+	c, err := h.newConsoleAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, res, err := c.ProjectAPI.GetProject(ctx, id).Execute()
+	if err != nil {
+		return nil, handleError("unable to get project", res, err)
+	}
+	if h.force {
+		return current, nil
+	}
+
+	base := h.projectBase(current.Id)
+	expected := h.ifMatch
+	if expected == "" && base != nil {
+		expected = base.RevisionId
+	}
+	if expected == "" || expected == current.RevisionId {
+		return current, nil
+	}
+
+	conflict := &ProjectConflictError{ProjectID: current.Id, Expected: expected, Actual: current.RevisionId}
+	if base == nil || base.RevisionId != expected {
+		// Without the project at the expected revision, the best we can do is
+		// to show what would be overwritten.
+		conflict.Ours = ours(current)
+		return nil, conflict
+	}
+
+	live, err := toJSONObject(current)
+	if err != nil {
+		return nil, err
+	}
+	if theirs, err := planProject(base, live); err == nil {
+		conflict.Theirs = theirs.Changes
+	}
+	conflict.Ours = ours(base)
+	return nil, conflict
+}
+
+// patchChanges describes the operations of a JSON Patch as changes.
+func patchChanges(patches []client.JsonPatch) []ProjectChange {
+	changes := make([]ProjectChange, 0, len(patches))
+	for _, p := range patches {
+		if !slices.Contains([]string{ChangeAdd, ChangeReplace, ChangeRemove}, p.Op) {
+			continue
+		}
+		changes = append(changes, ProjectChange{Op: p.Op, Path: p.Path, Value: p.Value})
+	}
+	return changes
+}
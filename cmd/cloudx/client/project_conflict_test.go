@@ -0,0 +1,152 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloud "github.com/ory/client-go"
+)
+
+func TestProjectRevisionConflict(t *testing.T) {
+	const projectID = "ecaaa3cb-0730-4ee8-a6df-9553cdfeef89"
+
+	var (
+		mu      sync.Mutex
+		project = cloud.Project{
+			Id:            projectID,
+			Name:          "Example",
+			Slug:          "example",
+			State:         "running",
+			Environment:   "prod",
+			HomeRegion:    "eu-central",
+			Organizations: []cloud.BasicOrganization{},
+			RevisionId:    "rev-1",
+			Services: cloud.ProjectServices{
+				Identity: &cloud.ProjectServiceIdentity{Config: map[string]any{
+					"session": map[string]any{"lifespan": "24h"},
+				}},
+			},
+		}
+		patches int
+	)
+	// someoneElse changes the project, as if another user updated it.
+	someoneElse := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		project.RevisionId = "rev-2"
+		project.Services.Identity.Config = map[string]any{"session": map[string]any{"lifespan": "48h"}}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /projects/" + projectID:
+			_ = json.NewEncoder(w).Encode(project)
+		case "PATCH /projects/" + projectID:
+			patches++
+			_ = json.NewEncoder(w).Encode(cloud.SuccessfulProjectUpdate{Project: project, Warnings: []cloud.Warning{}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	ctx := context.Background()
+	newHelper := func(opts ...CommandHelperOption) *CommandHelper {
+		h := &CommandHelper{cloudConsoleAPIURL: &ts.URL, workspaceAPIKey: new("api-key"), configLocation: filepath.Join(t.TempDir(), "config.json"), VerboseErrWriter: io.Discard}
+		for _, o := range opts {
+			o(h)
+		}
+		return h
+	}
+	replace := []string{`/services/identity/config/session/lifespan="12h"`}
+
+	t.Run("case=changed since read", func(t *testing.T) {
+		h := newHelper()
+		_, err := h.GetProject(ctx, projectID, nil)
+		require.NoError(t, err)
+
+		someoneElse()
+
+		_, err = h.PatchProject(ctx, projectID, nil, nil, replace, nil)
+		var conflict *ProjectConflictError
+		require.True(t, errors.As(err, &conflict), "%+v", err)
+		assert.Equal(t, "rev-1", conflict.Expected)
+		assert.Equal(t, "rev-2", conflict.Actual)
+		assert.Equal(t, []ProjectChange{{Op: ChangeReplace, Path: "/services/identity/config/session/lifespan", From: "24h", Value: "48h"}}, conflict.Theirs)
+		assert.Equal(t, []string{"/services/identity/config/session/lifespan"}, conflict.Conflicts())
+		assert.Contains(t, err.Error(), `~ /services/identity/config/session/lifespan: "24h" -> "48h"`)
+		assert.Contains(t, err.Error(), "--force")
+		assert.Zero(t, patches, "the project must not be written")
+
+		t.Run("case=force", func(t *testing.T) {
+			WithForce(true)(h)
+			_, err := h.PatchProject(ctx, projectID, nil, nil, replace, nil)
+			require.NoError(t, err)
+			assert.Equal(t, 1, patches)
+		})
+	})
+
+	t.Run("case=if-match", func(t *testing.T) {
+		_, err := newHelper(WithIfMatch("rev-2")).PatchProject(ctx, projectID, nil, nil, replace, nil)
+		require.NoError(t, err)
+
+		_, err = newHelper(WithIfMatch("rev-0")).PatchProject(ctx, projectID, nil, nil, replace, nil)
+		var conflict *ProjectConflictError
+		require.True(t, errors.As(err, &conflict), "%+v", err)
+		assert.Empty(t, conflict.Theirs, "the project at the expected revision is unknown")
+		assert.Len(t, conflict.Ours, 1)
+	})
+
+	t.Run("case=changed since read by another command", func(t *testing.T) {
+		config := filepath.Join(t.TempDir(), "config.json")
+		withConfig := func(h *CommandHelper) { h.configLocation = config }
+		_, err := newHelper(withConfig).GetProject(ctx, projectID, nil)
+		require.NoError(t, err)
+
+		mu.Lock()
+		project.RevisionId = "rev-3"
+		project.Services.Identity.Config = map[string]any{"session": map[string]any{"lifespan": "72h"}}
+		mu.Unlock()
+
+		written := patches
+		_, err = newHelper(withConfig).UpdateProject(ctx, projectID, "", []json.RawMessage{[]byte(`{"services": {"identity": {"config": {"session": {"lifespan": "12h"}}}}}`)})
+		var conflict *ProjectConflictError
+		require.True(t, errors.As(err, &conflict), "%+v", err)
+		assert.Equal(t, "rev-3", conflict.Actual)
+		assert.Equal(t, []ProjectChange{{Op: ChangeReplace, Path: "/services/identity/config/session/lifespan", From: "48h", Value: "72h"}}, conflict.Theirs)
+		assert.Equal(t, []string{"/services/identity/config/session/lifespan"}, conflict.Conflicts())
+		assert.Contains(t, err.Error(), "ory get project "+projectID)
+		assert.Equal(t, written, patches, "the project must not be written")
+
+		_, err = newHelper(withConfig).GetProject(ctx, projectID, nil)
+		require.NoError(t, err)
+		_, err = newHelper(withConfig).PatchProject(ctx, projectID, nil, nil, replace, nil)
+		assert.NoError(t, err, "the change is based on the project read again")
+	})
+
+	t.Run("case=written by this command", func(t *testing.T) {
+		h := newHelper()
+		_, err := h.GetProject(ctx, projectID, nil)
+		require.NoError(t, err)
+		_, err = h.PatchProject(ctx, projectID, nil, nil, replace, nil)
+		require.NoError(t, err)
+		_, err = h.PatchProject(ctx, projectID, nil, nil, replace, nil)
+		assert.NoError(t, err, "a command's own writes are no conflict")
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+
+	cloud "github.com/ory/client-go"
+)
+
+const exportPageSize = 500
+
+// ListOAuth2Clients returns all OAuth2 clients of the project, following the
+// pagination.
+func (h *CommandHelper) ListOAuth2Clients(ctx context.Context, slug string) ([]cloud.OAuth2Client, error) {
+	c, err := h.newProjectAPIClient(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make([]cloud.OAuth2Client, 0)
+	for token := ""; ; {
+		req := c.OAuth2API.ListOAuth2Clients(ctx).PageSize(exportPageSize)
+		if token != "" {
+			req = req.PageToken(token)
+		}
+		page, res, err := req.Execute()
+		if err != nil {
+			return nil, handleError("unable to list OAuth2 clients", res, err)
+		}
+		clients = append(clients, page...)
+		if token = nextPageToken(res); token == "" || len(page) == 0 {
+			return clients, nil
+		}
+	}
+}
+
+// ListIdentities returns all identities of the project, following the
+// pagination. Credentials are not included.
+func (h *CommandHelper) ListIdentities(ctx context.Context, slug string) ([]cloud.Identity, error) {
+	c, err := h.newProjectAPIClient(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	identities := make([]cloud.Identity, 0)
+	for token := ""; ; {
+		req := c.IdentityAPI.ListIdentities(ctx).PageSize(exportPageSize)
+		if token != "" {
+			req = req.PageToken(token)
+		}
+		page, res, err := req.Execute()
+		if err != nil {
+			return nil, handleError("unable to list identities", res, err)
+		}
+		identities = append(identities, page...)
+		if token = nextPageToken(res); token == "" || len(page) == 0 {
+			return identities, nil
+		}
+	}
+}
+
+// ListRelationships returns all relationships of the project, following the
+// pagination.
+func (h *CommandHelper) ListRelationships(ctx context.Context, slug string) ([]cloud.Relationship, error) {
+	c, err := h.newProjectAPIClient(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	relationships := make([]cloud.Relationship, 0)
+	for token := ""; ; {
+		req := c.RelationshipAPI.GetRelationships(ctx).PageSize(exportPageSize)
+		if token != "" {
+			req = req.PageToken(token)
+		}
+		page, res, err := req.Execute()
+		if err != nil {
+			return nil, handleError("unable to list relationships", res, err)
+		}
+		relationships = append(relationships, page.RelationTuples...)
+		if token = page.GetNextPageToken(); token == "" || len(page.RelationTuples) == 0 {
+			return relationships, nil
+		}
+	}
+}
+
+func (h *CommandHelper) CreateOAuth2Client(ctx context.Context, slug string, body cloud.OAuth2Client) (*cloud.OAuth2Client, error) {
+	c, err := h.newProjectAPIClient(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	created, res, err := c.OAuth2API.CreateOAuth2Client(ctx).OAuth2Client(body).Execute()
+	if err != nil {
+		return nil, handleError("unable to create OAuth2 client", res, err)
+	}
+	return created, nil
+}
+
+func (h *CommandHelper) SetOAuth2Client(ctx context.Context, slug, id string, body cloud.OAuth2Client) (*cloud.OAuth2Client, error) {
+	c, err := h.newProjectAPIClient(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, res, err := c.OAuth2API.SetOAuth2Client(ctx, id).OAuth2Client(body).Execute()
+	if err != nil {
+		return nil, handleError("unable to update OAuth2 client", res, err)
+	}
+	return updated, nil
+}
+
+func (h *CommandHelper) CreateIdentity(ctx context.Context, slug string, body cloud.CreateIdentityBody) (*cloud.Identity, error) {
+	c, err := h.newProjectAPIClient(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, res, err := c.IdentityAPI.CreateIdentity(ctx).CreateIdentityBody(body).Execute()
+	if err != nil {
+		return nil, handleError("unable to create identity", res, err)
+	}
+	return identity, nil
+}
+
+// CreateRelationships inserts the relationships in a single transaction.
+func (h *CommandHelper) CreateRelationships(ctx context.Context, slug string, relationships []cloud.Relationship) error {
+	c, err := h.newProjectAPIClient(ctx, slug)
+	if err != nil {
+		return err
+	}
+
+	patch := make([]cloud.RelationshipPatch, len(relationships))
+	for i := range relationships {
+		patch[i] = cloud.RelationshipPatch{Action: new("insert"), RelationTuple: &relationships[i]}
+	}
+	res, err := c.RelationshipAPI.PatchRelationships(ctx).RelationshipPatch(patch).Execute()
+	if err != nil {
+		return handleError("unable to create relationships", res, err)
+	}
+	return nil
+}
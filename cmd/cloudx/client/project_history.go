@@ -0,0 +1,170 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/ory/client-go"
+)
+
+type (
+	// ProjectHistoryEntry is the configuration a project had before the CLI
+	// changed it.
+	ProjectHistoryEntry struct {
+		// Revision numbers the entries of a project, starting at 1.
+		Revision  int       `json:"revision"`
+		CreatedAt time.Time `json:"created_at"`
+		User      string    `json:"user"`
+		// Project is the project as it was before the change.
+		Project *client.Project `json:"project"`
+	}
+	ProjectHistory []ProjectHistoryEntry
+)
+
+// projectHistoryPath returns the journal of the project. The journals are kept
+// next to the config file, one file per project with one entry per line.
+func (h *CommandHelper) projectHistoryPath(projectID string) string {
+	return filepath.Join(h.configLocation+".history", projectID+".jsonl")
+}
+
+// recordProjectHistory appends the project as it was before a change to its
+// journal. The change was already made, so failing to record it is only
+// reported.
+func (h *CommandHelper) recordProjectHistory(ctx context.Context, before *client.Project) {
+	if err := h.appendProjectHistory(ctx, before); err != nil {
+		_, _ = fmt.Fprintf(h.VerboseErrWriter, "Unable to record the previous configuration of project %s in the history: %s\n", before.Id, err)
+	}
+}
+
+func (h *CommandHelper) appendProjectHistory(ctx context.Context, before *client.Project) error {
+	history, err := h.ProjectHistory(before.Id)
+	if err != nil {
+		return err
+	}
+
+	// Do not sign in just to find out the user's name when using API keys.
+	user := "API key"
+	if h.workspaceAPIKey == nil && h.projectAPIKey == nil {
+		user = h.UserName(ctx)
+	}
+	raw, err := json.Marshal(ProjectHistoryEntry{
+		Revision:  len(history) + 1,
+		CreatedAt: time.Now().UTC().Round(time.Second),
+		User:      user,
+		Project:   before,
+	})
+	if err != nil {
+		return err
+	}
+
+	path := h.projectHistoryPath(before.Id)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	// The configuration contains secrets, such as SMTP credentials.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(raw, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ProjectHistory returns the journal of the project, oldest entry first.
+func (h *CommandHelper) ProjectHistory(projectID string) (ProjectHistory, error) {
+	f, err := os.Open(h.projectHistoryPath(projectID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ProjectHistory{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read the project history: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	history := ProjectHistory{}
+	scanner := bufio.NewScanner(f)
+	// A project's configuration can be larger than the default limit.
+	scanner.Buffer(nil, 64<<20)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry ProjectHistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("unable to decode the project history: %w", err)
+		}
+		history = append(history, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read the project history: %w", err)
+	}
+	return history, nil
+}
+
+// ProjectHistoryEntry returns the entry of the project with the revision.
+func (h *CommandHelper) ProjectHistoryEntry(projectID string, revision int) (*ProjectHistoryEntry, error) {
+	history, err := h.ProjectHistory(projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range history {
+		if e.Revision == revision {
+			return &e, nil
+		}
+	}
+	if len(history) == 0 {
+		return nil, fmt.Errorf("there is no history for project %s, it is recorded when the project is changed using the CLI", projectID)
+	}
+	return nil, fmt.Errorf("revision %d of project %s does not exist, the revisions are 1 to %d", revision, projectID, len(history))
+}
+
+// PlanRollback plans restoring the configuration of the project to the one
+// recorded with the revision.
+func (h *CommandHelper) PlanRollback(ctx context.Context, projectID string, revision int) (*ProjectPlan, error) {
+	entry, err := h.ProjectHistoryEntry(projectID, revision)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := toJSONObject(entry.Project)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(desired)
+	if err != nil {
+		return nil, err
+	}
+	return h.PlanProject(ctx, projectID, []json.RawMessage{raw})
+}
+
+func (ProjectHistory) Header() []string {
+	return []string{"REVISION", "CREATED AT", "USER", "PROJECT REVISION ID"}
+}
+
+func (h ProjectHistory) Table() [][]string {
+	rows := make([][]string, len(h))
+	for i, e := range h {
+		rows[i] = []string{strconv.Itoa(e.Revision), e.CreatedAt.Format(time.RFC3339), e.User, e.Project.RevisionId}
+	}
+	return rows
+}
+
+func (h ProjectHistory) Interface() interface{} {
+	return h
+}
+
+func (h ProjectHistory) Len() int {
+	return len(h)
+}
@@ -0,0 +1,122 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloud "github.com/ory/client-go"
+)
+
+func TestProjectHistory(t *testing.T) {
+	const projectID = "ecaaa3cb-0730-4ee8-a6df-9553cdfeef89"
+
+	var (
+		mu      sync.Mutex
+		project = cloud.Project{
+			Id:            projectID,
+			Name:          "Example",
+			Slug:          "example",
+			State:         "running",
+			Environment:   "prod",
+			HomeRegion:    "eu-central",
+			Organizations: []cloud.BasicOrganization{},
+			RevisionId:    "rev-1",
+			Services: cloud.ProjectServices{
+				Identity: &cloud.ProjectServiceIdentity{Config: map[string]any{
+					"session": map[string]any{"lifespan": "24h"},
+				}},
+			},
+		}
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /projects/" + projectID:
+			_ = json.NewEncoder(w).Encode(project)
+		case "PATCH /projects/" + projectID:
+			patch, err := jsonpatch.DecodePatch(must(io.ReadAll(r.Body)))
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			raw, _ := json.Marshal(project)
+			_ = json.Unmarshal(must(patch.Apply(raw)), &project)
+			project.RevisionId += "+"
+			_ = json.NewEncoder(w).Encode(cloud.SuccessfulProjectUpdate{Project: project, Warnings: []cloud.Warning{}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	ctx := context.Background()
+	h := &CommandHelper{
+		cloudConsoleAPIURL: &ts.URL,
+		workspaceAPIKey:    new("api-key"),
+		configLocation:     filepath.Join(t.TempDir(), "config.json"),
+		VerboseErrWriter:   io.Discard,
+	}
+
+	history, err := h.ProjectHistory(projectID)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+	_, err = h.ProjectHistoryEntry(projectID, 1)
+	assert.ErrorContains(t, err, "there is no history")
+
+	_, err = h.PatchProject(ctx, projectID, nil, nil, []string{`/services/identity/config/session/lifespan="48h"`}, nil)
+	require.NoError(t, err)
+	_, err = h.PatchProject(ctx, projectID, nil, nil, []string{`/services/identity/config/session/lifespan="72h"`}, nil)
+	require.NoError(t, err)
+
+	history, err = h.ProjectHistory(projectID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, 1, history[0].Revision)
+	assert.Equal(t, "API key", history[0].User)
+	assert.Equal(t, "rev-1", history[0].Project.RevisionId)
+	assert.Equal(t, "24h", history[0].Project.Services.Identity.Config["session"].(map[string]any)["lifespan"])
+	assert.Equal(t, 2, history[1].Revision)
+	assert.Equal(t, "48h", history[1].Project.Services.Identity.Config["session"].(map[string]any)["lifespan"])
+
+	fi, err := os.Stat(h.projectHistoryPath(projectID))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm(), "the history contains secrets")
+
+	_, err = h.ProjectHistoryEntry(projectID, 3)
+	assert.ErrorContains(t, err, "the revisions are 1 to 2")
+
+	plan, err := h.PlanRollback(ctx, projectID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []ProjectChange{{Op: ChangeReplace, Path: "/services/identity/config/session/lifespan", From: "72h", Value: "24h"}}, plan.Changes)
+
+	_, err = h.ApplyProjectPlan(ctx, plan)
+	require.NoError(t, err)
+	history, err = h.ProjectHistory(projectID)
+	require.NoError(t, err)
+	require.Len(t, history, 3, "the rollback is recorded as well")
+	assert.Equal(t, "72h", history[2].Project.Services.Identity.Config["session"].(map[string]any)["lifespan"])
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,251 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/client-go"
+)
+
+// projectConfigSections are the parts of a project managed by `ory plan` and
+// `ory apply`. Sections missing from the desired configuration are left alone,
+// everything within a section present in it is replaced.
+var projectConfigSections = []string{
+	"/name",
+	"/cors_admin",
+	"/cors_public",
+	"/services/identity/config",
+	"/services/permission/config",
+	"/services/oauth2/config",
+	"/services/account_experience/config",
+}
+
+const (
+	ChangeAdd     = "add"
+	ChangeReplace = "replace"
+	ChangeRemove  = "remove"
+)
+
+type (
+	// ProjectChange is a single change of a project's configuration. It maps
+	// one-to-one to a JSON Patch operation.
+	ProjectChange struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		From  any    `json:"from,omitempty"`
+		Value any    `json:"value,omitempty"`
+	}
+
+	// ProjectPlan is the set of changes that bring a project's live
+	// configuration to the desired one.
+	ProjectPlan struct {
+		ProjectID string          `json:"project_id"`
+		Changes   []ProjectChange `json:"changes"`
+	}
+)
+
+// PlanProject computes the changes needed to bring the live configuration of
+// the project to the one described by configs. The configs are merged the same
+// way as for UpdateProject.
+func (h *CommandHelper) PlanProject(ctx context.Context, id string, configs []json.RawMessage) (*ProjectPlan, error) {
+	desired, err := mergeProjectConfigs(configs)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := h.newConsoleAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	project, res, err := c.ProjectAPI.GetProject(ctx, id).Execute()
+	if err != nil {
+		return nil, handleError("unable to get project", res, err)
+	}
+	h.rememberProject(project)
+
+	return planProject(project, desired)
+}
+
+func planProject(project *client.Project, desired map[string]any) (*ProjectPlan, error) {
+	raw, err := json.Marshal(project)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var live map[string]any
+	if err := json.Unmarshal(raw, &live); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	plan := &ProjectPlan{ProjectID: project.Id, Changes: []ProjectChange{}}
+	found := false
+	for _, section := range projectConfigSections {
+		to, ok := lookupJSONPointer(desired, section)
+		if !ok {
+			continue
+		}
+		found = true
+		from, ok := lookupJSONPointer(live, section)
+		if !ok {
+			plan.Changes = append(plan.Changes, ProjectChange{Op: ChangeAdd, Path: section, Value: to})
+			continue
+		}
+		plan.Changes = diffJSON(section, from, to, plan.Changes)
+	}
+	if !found {
+		return nil, errors.Errorf("the configuration must set at least one of %s", strings.Join(projectConfigSections, ", "))
+	}
+	return plan, nil
+}
+
+// ApplyProjectPlan sends the changes of the plan to the project as a JSON Patch.
+func (h *CommandHelper) ApplyProjectPlan(ctx context.Context, plan *ProjectPlan) (*client.SuccessfulProjectUpdate, error) {
+	c, err := h.newConsoleAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := h.checkProjectRevision(ctx, plan.ProjectID, func(*client.Project) []ProjectChange { return plan.Changes })
+	if err != nil {
+		return nil, err
+	}
+
+	res, raw, err := c.ProjectAPI.PatchProject(ctx, plan.ProjectID).JsonPatch(plan.Patch()).Execute()
+	if err != nil {
+		return nil, handleError("unable to apply the project configuration", raw, err)
+	}
+	h.rememberProject(&res.Project)
+	h.recordProjectHistory(ctx, before)
+	return res, nil
+}
+
+// diffJSON appends the changes turning from into to. Objects are compared key
+// by key, so that only the keys that changed are patched. Arrays are replaced
+// as a whole, as patching them index by index is ambiguous when elements are
+// inserted or removed.
+func diffJSON(path string, from, to any, changes []ProjectChange) []ProjectChange {
+	fromObj, fromIsObj := from.(map[string]any)
+	toObj, toIsObj := to.(map[string]any)
+	if !fromIsObj || !toIsObj {
+		if !reflect.DeepEqual(from, to) {
+			changes = append(changes, ProjectChange{Op: ChangeReplace, Path: path, From: from, Value: to})
+		}
+		return changes
+	}
+
+	keys := slices.Sorted(maps.Keys(fromObj))
+	for k := range toObj {
+		if _, ok := fromObj[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		p := path + "/" + escapeJSONPointer(k)
+		f, inFrom := fromObj[k]
+		t, inTo := toObj[k]
+		switch {
+		case !inTo:
+			changes = append(changes, ProjectChange{Op: ChangeRemove, Path: p, From: f})
+		case !inFrom:
+			changes = append(changes, ProjectChange{Op: ChangeAdd, Path: p, Value: t})
+		default:
+			changes = diffJSON(p, f, t, changes)
+		}
+	}
+	return changes
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+func lookupJSONPointer(doc map[string]any, pointer string) (any, bool) {
+	var current any = doc
+	for _, part := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		obj, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = obj[strings.NewReplacer("~1", "/", "~0", "~").Replace(part)]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// Patch returns the JSON Patch applying the plan.
+func (p *ProjectPlan) Patch() []client.JsonPatch {
+	patch := make([]client.JsonPatch, len(p.Changes))
+	for i, c := range p.Changes {
+		patch[i] = client.JsonPatch{Op: c.Op, Path: c.Path}
+		switch {
+		case c.Op == ChangeRemove:
+		case c.Value == nil:
+			// The value is omitted if empty, but add and replace require it.
+			patch[i].Value = json.RawMessage("null")
+		default:
+			patch[i].Value = c.Value
+		}
+	}
+	return patch
+}
+
+// Empty reports whether the project already matches the desired configuration.
+func (p *ProjectPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String renders the plan for humans, similar to `terraform plan`.
+func (p *ProjectPlan) String() string {
+	if p.Empty() {
+		return fmt.Sprintf("No changes. Project %s matches the configuration.\n", p.ProjectID)
+	}
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "Project %s will be changed as follows:\n\n", p.ProjectID)
+	writeProjectChanges(&b, p.Changes)
+	counts := map[string]int{}
+	for _, c := range p.Changes {
+		counts[c.Op]++
+	}
+	_, _ = fmt.Fprintf(&b, "\nPlan: %d to add, %d to change, %d to remove.\n", counts[ChangeAdd], counts[ChangeReplace], counts[ChangeRemove])
+	return b.String()
+}
+
+func writeProjectChanges(w io.Writer, changes []ProjectChange) {
+	for _, c := range changes {
+		switch c.Op {
+		case ChangeAdd:
+			_, _ = fmt.Fprintf(w, "  + %s = %s\n", c.Path, formatPlanValue(c.Value))
+		case ChangeRemove:
+			_, _ = fmt.Fprintf(w, "  - %s = %s\n", c.Path, formatPlanValue(c.From))
+		case ChangeReplace:
+			_, _ = fmt.Fprintf(w, "  ~ %s: %s -> %s\n", c.Path, formatPlanValue(c.From), formatPlanValue(c.Value))
+		}
+	}
+}
+
+func (p *ProjectPlan) Interface() any {
+	return p
+}
+
+func formatPlanValue(v any) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(raw)
+}
@@ -0,0 +1,106 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloud "github.com/ory/client-go"
+)
+
+func TestPlanProject(t *testing.T) {
+	project := &cloud.Project{
+		Id:   "ecaaa3cb-0730-4ee8-a6df-9553cdfeef89",
+		Name: "Example",
+		CorsPublic: &cloud.ProjectCors{
+			Enabled: new(true),
+			Origins: []string{"https://old.example.com"},
+		},
+		Services: cloud.ProjectServices{
+			Identity: &cloud.ProjectServiceIdentity{Config: map[string]any{
+				"courier": map[string]any{"smtp": map[string]any{"from_name": "Acme"}},
+				"selfservice": map[string]any{
+					"methods": map[string]any{
+						"password": map[string]any{"enabled": true},
+						"totp":     map[string]any{"enabled": false},
+					},
+				},
+				"session": map[string]any{"lifespan": "24h"},
+			}},
+			Permission: &cloud.ProjectServicePermission{Config: map[string]any{"namespaces": []any{}}},
+		},
+	}
+
+	var desired map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "id": "ignored",
+  "name": "Example",
+  "cors_public": {"enabled": true, "origins": ["https://new.example.com"]},
+  "services": {
+    "identity": {
+      "config": {
+        "courier": {"smtp": {"from_name": "Acme Inc."}},
+        "selfservice": {
+          "methods": {
+            "password": {"enabled": true},
+            "totp": {"enabled": false},
+            "code/otp": {"enabled": true}
+          }
+        }
+      }
+    }
+  }
+}`), &desired))
+
+	plan, err := planProject(project, desired)
+	require.NoError(t, err)
+
+	assert.Equal(t, []ProjectChange{
+		{Op: ChangeReplace, Path: "/cors_public/origins", From: []any{"https://old.example.com"}, Value: []any{"https://new.example.com"}},
+		{Op: ChangeReplace, Path: "/services/identity/config/courier/smtp/from_name", From: "Acme", Value: "Acme Inc."},
+		{Op: ChangeAdd, Path: "/services/identity/config/selfservice/methods/code~1otp", Value: map[string]any{"enabled": true}},
+		{Op: ChangeRemove, Path: "/services/identity/config/session", From: map[string]any{"lifespan": "24h"}},
+	}, plan.Changes, "the permission config is not part of the desired configuration and must not be touched")
+
+	assert.Equal(t, []cloud.JsonPatch{
+		{Op: "replace", Path: "/cors_public/origins", Value: []any{"https://new.example.com"}},
+		{Op: "replace", Path: "/services/identity/config/courier/smtp/from_name", Value: "Acme Inc."},
+		{Op: "add", Path: "/services/identity/config/selfservice/methods/code~1otp", Value: map[string]any{"enabled": true}},
+		{Op: "remove", Path: "/services/identity/config/session"},
+	}, plan.Patch())
+
+	assert.Contains(t, plan.String(), `~ /services/identity/config/courier/smtp/from_name: "Acme" -> "Acme Inc."`)
+	assert.Contains(t, plan.String(), "Plan: 1 to add, 2 to change, 1 to remove.")
+
+	t.Run("case=no changes", func(t *testing.T) {
+		plan, err := planProject(project, map[string]any{"name": "Example"})
+		require.NoError(t, err)
+		assert.True(t, plan.Empty())
+		assert.Contains(t, plan.String(), "No changes.")
+	})
+
+	t.Run("case=null values", func(t *testing.T) {
+		var desired map[string]any
+		require.NoError(t, json.Unmarshal([]byte(`{"services": {"identity": {"config": {"courier": {"smtp": {"from_name": null}}, "session": {"lifespan": "24h"}, "hooks": null}}}}`), &desired))
+		plan, err := planProject(project, desired)
+		require.NoError(t, err)
+
+		raw, err := json.Marshal(plan.Patch())
+		require.NoError(t, err)
+		assert.JSONEq(t, `[
+  {"op": "replace", "path": "/services/identity/config/courier/smtp/from_name", "value": null},
+  {"op": "add", "path": "/services/identity/config/hooks", "value": null},
+  {"op": "remove", "path": "/services/identity/config/selfservice"}
+]`, string(raw))
+	})
+
+	t.Run("case=nothing to compare", func(t *testing.T) {
+		_, err := planProject(project, map[string]any{"slug": "example"})
+		assert.ErrorContains(t, err, "at least one of")
+	})
+}
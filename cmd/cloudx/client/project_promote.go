@@ -0,0 +1,282 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/client-go"
+)
+
+// DefaultPromotionExcludes are the JSON pointers that are specific to an
+// environment and therefore not copied when promoting a project. A "*" segment
+// matches every key of an object or every element of an array.
+var DefaultPromotionExcludes = []string{
+	"/services/identity/config/serve/*/base_url",
+	"/services/identity/config/cookies/domain",
+	"/services/identity/config/session/cookie/domain",
+	"/services/identity/config/secrets",
+	"/services/identity/config/courier/smtp",
+	"/services/identity/config/courier/http",
+	"/services/identity/config/selfservice/default_browser_return_url",
+	"/services/identity/config/selfservice/allowed_return_urls",
+	"/services/identity/config/selfservice/flows/*/ui_url",
+	"/services/identity/config/selfservice/methods/oidc/config/providers/*/client_secret",
+	"/services/oauth2/config/urls",
+	"/services/oauth2/config/secrets",
+}
+
+// promotedServices are the services whose configuration is copied when
+// promoting a project. The name, CORS settings, and organizations belong to
+// the target project.
+var promotedServices = []string{"identity", "permission", "oauth2", "account_experience"}
+
+// ProjectPromotion is the result of planning to copy the configuration of one
+// project to another.
+type ProjectPromotion struct {
+	From *client.Project `json:"-"`
+	To   *client.Project `json:"-"`
+	// Config is the configuration the target project will have.
+	Config map[string]any `json:"-"`
+	Plan   *ProjectPlan   `json:"plan"`
+}
+
+// PlanPromotion plans copying the service configuration of project from to
+// project to. The values at the excluded JSON pointers are kept as they are in
+// the target project.
+func (h *CommandHelper) PlanPromotion(ctx context.Context, from, to string, excludes []string) (*ProjectPromotion, error) {
+	source, err := h.GetProject(ctx, from, h.WorkspaceID())
+	if err != nil {
+		return nil, err
+	}
+	target, err := h.GetProject(ctx, to, h.WorkspaceID())
+	if err != nil {
+		return nil, err
+	}
+	if source.Id == target.Id {
+		return nil, errors.Errorf("the source and target project must be different, but both are %s", source.Id)
+	}
+
+	desired, err := promoteProject(source, target, excludes)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := planProject(target, desired)
+	if err != nil {
+		return nil, err
+	}
+	return &ProjectPromotion{From: source, To: target, Config: desired, Plan: plan}, nil
+}
+
+// ApplyPromotion replaces the service configuration of the target project.
+func (h *CommandHelper) ApplyPromotion(ctx context.Context, p *ProjectPromotion) (*client.SuccessfulProjectUpdate, error) {
+	raw, err := json.Marshal(p.Config)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return h.UpdateProject(ctx, p.To.Id, "", []json.RawMessage{raw})
+}
+
+func promoteProject(from, to *client.Project, excludes []string) (map[string]any, error) {
+	source, err := toJSONObject(from)
+	if err != nil {
+		return nil, err
+	}
+	target, err := toJSONObject(to)
+	if err != nil {
+		return nil, err
+	}
+
+	services := map[string]any{}
+	for _, s := range promotedServices {
+		if config, ok := lookupJSONPointer(source, "/services/"+s+"/config"); ok {
+			services[s] = map[string]any{"config": config}
+		}
+	}
+	return keepExcludes(map[string]any{"services": services}, target, excludes)
+}
+
+// KeepExcludedConfig sets the values at the excluded JSON pointers of the
+// desired project configuration to the ones the live project has, or removes
+// them if it has none.
+func KeepExcludedConfig(desired map[string]any, live *client.Project, excludes []string) (map[string]any, error) {
+	target, err := toJSONObject(live)
+	if err != nil {
+		return nil, err
+	}
+	return keepExcludes(desired, target, excludes)
+}
+
+func keepExcludes(desired, target map[string]any, excludes []string) (map[string]any, error) {
+	for _, pointer := range excludes {
+		if !strings.HasPrefix(pointer, "/") {
+			return nil, errors.Errorf("the exclude %q is not a JSON pointer, it must start with a slash", pointer)
+		}
+		desired = keepJSONPointer(desired, target, splitJSONPointer(pointer)).(map[string]any)
+	}
+	return desired, nil
+}
+
+func toJSONObject(v any) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return obj, nil
+}
+
+func splitJSONPointer(pointer string) []string {
+	parts := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, p := range parts {
+		parts[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(p)
+	}
+	return parts
+}
+
+// keepJSONPointer sets the values in desired matched by the pointer parts to
+// the ones in live, removing them from desired if live does not have them, and
+// returns the updated desired value. Objects missing in desired are created if
+// live has a value below them, so that the target keeps it even if the source
+// does not configure the parent. Arrays and their elements are never created.
+func keepJSONPointer(desired, live any, parts []string) any {
+	keys := []string{parts[0]}
+	if parts[0] == "*" {
+		keys = childKeys(desired)
+		if isJSONObject(desired) && isJSONObject(live) {
+			for _, key := range childKeys(live) {
+				if !slices.Contains(keys, key) {
+					keys = append(keys, key)
+				}
+			}
+		}
+	}
+
+	var drop []int
+	for _, key := range keys {
+		child, ok := jsonChild(desired, key)
+		liveChild, liveOK := liveCounterpart(live, child, key)
+		if !ok && !liveOK {
+			continue
+		}
+
+		if len(parts) > 1 {
+			if ok {
+				setJSONChild(desired, key, keepJSONPointer(child, liveChild, parts[1:]))
+			} else if d, isObject := desired.(map[string]any); isObject && isJSONObject(liveChild) {
+				if kept, _ := keepJSONPointer(map[string]any{}, liveChild, parts[1:]).(map[string]any); len(kept) > 0 {
+					d[key] = kept
+				}
+			}
+			continue
+		}
+
+		switch d := desired.(type) {
+		case map[string]any:
+			if liveOK {
+				d[key] = liveChild
+			} else {
+				delete(d, key)
+			}
+		case []any:
+			if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(d) {
+				if liveOK {
+					d[i] = liveChild
+				} else {
+					drop = append(drop, i)
+				}
+			}
+		}
+	}
+
+	if d, ok := desired.([]any); ok && len(drop) > 0 {
+		kept := make([]any, 0, len(d)-len(drop))
+		for i, v := range d {
+			if !slices.Contains(drop, i) {
+				kept = append(kept, v)
+			}
+		}
+		return kept
+	}
+	return desired
+}
+
+// liveCounterpart finds the live value corresponding to the desired child at
+// key. Array elements with an "id", such as social sign-in providers, are
+// matched by that id, as the projects may list them in a different order.
+func liveCounterpart(live, desiredChild any, key string) (any, bool) {
+	elements, isArray := live.([]any)
+	obj, isObject := desiredChild.(map[string]any)
+	if !isArray || !isObject {
+		return jsonChild(live, key)
+	}
+	id, ok := obj["id"]
+	if !ok {
+		return jsonChild(live, key)
+	}
+	for _, e := range elements {
+		if candidate, ok := e.(map[string]any); ok && candidate["id"] == id {
+			return candidate, true
+		}
+	}
+	return nil, false
+}
+
+func isJSONObject(v any) bool {
+	_, ok := v.(map[string]any)
+	return ok
+}
+
+func setJSONChild(v any, key string, child any) {
+	switch v := v.(type) {
+	case map[string]any:
+		v[key] = child
+	case []any:
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(v) {
+			v[i] = child
+		}
+	}
+}
+
+func childKeys(v any) []string {
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		return keys
+	case []any:
+		keys := make([]string, len(v))
+		for i := range v {
+			keys[i] = strconv.Itoa(i)
+		}
+		return keys
+	}
+	return nil
+}
+
+func jsonChild(v any, key string) (any, bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		child, ok := v[key]
+		return child, ok
+	case []any:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(v) {
+			return nil, false
+		}
+		return v[i], true
+	}
+	return nil, false
+}
@@ -0,0 +1,19 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	hydraspec "github.com/ory/hydra/v2/spec"
+	ketoembedx "github.com/ory/keto/embedx"
+	kratosembedx "github.com/ory/kratos/embedx"
+)
+
+// projectConfigSchemas are the configuration schemas of the services, as
+// shipped with the versions of Ory Kratos, Ory Hydra, and Ory Keto the CLI is
+// built with.
+var projectConfigSchemas = []projectConfigSchema{
+	{Pointer: "/services/identity/config", ID: "ory://kratos-config", Schema: []byte(kratosembedx.ConfigSchema)},
+	{Pointer: "/services/oauth2/config", ID: "ory://hydra-config", Schema: hydraspec.ConfigValidationSchema},
+	{Pointer: "/services/permission/config", ID: "ory://keto-config", Schema: []byte(ketoembedx.ConfigSchema)},
+}
@@ -0,0 +1,348 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ory/client-go"
+	"github.com/ory/jsonschema/v3"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+)
+
+type (
+	// projectConfigSchema is the JSON schema of a service's configuration.
+	projectConfigSchema struct {
+		// Pointer is where the service's configuration is in a project.
+		Pointer string
+		// ID is used if the schema does not have an `$id`.
+		ID     string
+		Schema []byte
+	}
+
+	// ProjectConfigViolation is a single problem found when validating a
+	// project configuration.
+	ProjectConfigViolation struct {
+		Pointer string `json:"pointer"`
+		Message string `json:"message"`
+		Source  string `json:"source,omitempty"`
+		Line    int    `json:"line,omitempty"`
+	}
+
+	// ProjectConfigValidationError lists all problems of a project
+	// configuration.
+	ProjectConfigValidationError struct {
+		Violations []ProjectConfigViolation `json:"violations"`
+	}
+)
+
+func (e *ProjectConfigValidationError) Error() string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "the project configuration is invalid, found %d problem(s):\n", len(e.Violations))
+	for _, v := range e.Violations {
+		_, _ = fmt.Fprintf(&b, "\n  %s: %s", v.Pointer, v.Message)
+		if v.Source != "" {
+			_, _ = fmt.Fprintf(&b, "\n    in %s", v.Location())
+		}
+	}
+	return b.String()
+}
+
+// Location returns the file and line the violation comes from.
+func (v *ProjectConfigViolation) Location() string {
+	if v.Line > 0 {
+		return fmt.Sprintf("%s:%d", v.Source, v.Line)
+	}
+	return v.Source
+}
+
+func (e *ProjectConfigValidationError) Header() []string {
+	return []string{"POINTER", "MESSAGE", "SOURCE"}
+}
+
+func (e *ProjectConfigValidationError) Table() [][]string {
+	rows := make([][]string, len(e.Violations))
+	for i, v := range e.Violations {
+		source := v.Location()
+		if source == "" {
+			source = "-"
+		}
+		rows[i] = []string{v.Pointer, v.Message, source}
+	}
+	return rows
+}
+
+func (e *ProjectConfigValidationError) Interface() interface{} {
+	return e
+}
+
+func (e *ProjectConfigValidationError) Len() int {
+	return len(e.Violations)
+}
+
+// compiledProjectConfigSchemas compiles the schemas only once, and only when
+// a configuration is validated.
+var compiledProjectConfigSchemas = sync.OnceValues(func() (map[string]*jsonschema.Schema, error) {
+	return compileProjectConfigSchemas(projectConfigSchemas)
+})
+
+func compileProjectConfigSchemas(schemas []projectConfigSchema) (map[string]*jsonschema.Schema, error) {
+	compiled := make(map[string]*jsonschema.Schema, len(schemas))
+	for _, s := range schemas {
+		// The services' configuration in Ory Network omits the keys managed
+		// by Ory, such as the DSN, which the schemas require.
+		var schema map[string]any
+		if err := json.Unmarshal(s.Schema, &schema); err != nil {
+			return nil, errors.Wrapf(err, "unable to decode the schema of %s", s.Pointer)
+		}
+		delete(schema, "required")
+		raw, err := json.Marshal(schema)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		// References within the schema are resolved against its own ID.
+		id := s.ID
+		if schemaID, ok := schema["$id"].(string); ok && schemaID != "" {
+			id = schemaID
+		}
+
+		c := jsonschema.NewCompiler()
+		if err := c.AddResource(id, bytes.NewReader(raw)); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if err := otelx.AddConfigSchema(c); err != nil {
+			return nil, err
+		}
+		if err := logrusx.AddConfigSchema(c); err != nil {
+			return nil, err
+		}
+		compiled[s.Pointer], err = c.Compile(context.Background(), id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to compile the schema of %s", s.Pointer)
+		}
+	}
+	return compiled, nil
+}
+
+// ValidateProjectConfig merges the configs like UpdateProject and validates the
+// identity, permission, and OAuth2 configuration against the services' JSON
+// schemas. All violations are returned as a *ProjectConfigValidationError,
+// pointing to the sources they come from.
+func ValidateProjectConfig(configs []json.RawMessage, sources []ProjectConfigSource) error {
+	config, err := mergeProjectConfigs(configs)
+	if err != nil {
+		return err
+	}
+	schemas, err := compiledProjectConfigSchemas()
+	if err != nil {
+		return err
+	}
+	return validateProjectConfig(config, schemas, sources)
+}
+
+// ValidateProjectPatch validates the configuration the project will have
+// after applying the patch, which is built the same way as by PatchProject.
+// Violations point to the patch operations causing them.
+func (h *CommandHelper) ValidateProjectPatch(ctx context.Context, id string, raw []json.RawMessage, add, replace, del []string) error {
+	patches, err := projectPatches(raw, add, replace, del)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(patches, func(p client.JsonPatch) bool { return isServiceConfigPointer(p.Path) }) {
+		return nil
+	}
+
+	c, err := h.newConsoleAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+	project, res, err := c.ProjectAPI.GetProject(ctx, id).Execute()
+	if err != nil {
+		return handleError("unable to get project", res, err)
+	}
+	h.rememberProject(project)
+
+	doc, err := json.Marshal(project)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	patch, err := json.Marshal(patches)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	decoded, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	patched, err := decoded.Apply(doc)
+	if err != nil {
+		return errors.Wrap(err, "unable to apply the patch")
+	}
+	var config map[string]any
+	if err := json.Unmarshal(patched, &config); err != nil {
+		return errors.WithStack(err)
+	}
+
+	schemas, err := compiledProjectConfigSchemas()
+	if err != nil {
+		return err
+	}
+	if err := validateProjectConfig(config, schemas, nil); err != nil {
+		var verr *ProjectConfigValidationError
+		if errors.As(err, &verr) {
+			for i, v := range verr.Violations {
+				verr.Violations[i].Source = patchSource(patches, v.Pointer)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func isServiceConfigPointer(pointer string) bool {
+	for _, s := range projectConfigSchemas {
+		if pointer == s.Pointer || strings.HasPrefix(pointer, s.Pointer+"/") || strings.HasPrefix(s.Pointer, pointer+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// patchSource returns the last patch operation touching the pointer.
+func patchSource(patches []client.JsonPatch, pointer string) string {
+	for _, p := range slices.Backward(patches) {
+		if pointer == p.Path || strings.HasPrefix(pointer, p.Path+"/") || strings.HasPrefix(p.Path, pointer+"/") {
+			return p.Op + " " + p.Path
+		}
+	}
+	return ""
+}
+
+func validateProjectConfig(config map[string]any, schemas map[string]*jsonschema.Schema, sources []ProjectConfigSource) error {
+	var violations []ProjectConfigViolation
+	for _, pointer := range slices.Sorted(maps.Keys(schemas)) {
+		service, ok := lookupJSONPointer(config, pointer)
+		if !ok {
+			continue
+		}
+		err := schemas[pointer].ValidateInterface(service)
+		var verr *jsonschema.ValidationError
+		if errors.As(err, &verr) {
+			violations = appendViolations(violations, pointer, verr)
+		} else if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	for i, v := range violations {
+		violations[i].Source, violations[i].Line = locateJSONPointer(sources, v.Pointer)
+	}
+	return &ProjectConfigValidationError{Violations: violations}
+}
+
+// appendViolations flattens the validation error into its causes.
+func appendViolations(violations []ProjectConfigViolation, prefix string, err *jsonschema.ValidationError) []ProjectConfigViolation {
+	if len(err.Causes) > 0 {
+		for _, cause := range err.Causes {
+			violations = appendViolations(violations, prefix, cause)
+		}
+		return violations
+	}
+
+	pointer := prefix
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(err.InstancePtr, "#"), "/"), "/") {
+		if part == "" {
+			continue
+		}
+		if unescaped, uerr := url.PathUnescape(part); uerr == nil {
+			part = unescaped
+		}
+		pointer += "/" + part
+	}
+	return append(violations, ProjectConfigViolation{Pointer: pointer, Message: err.Message})
+}
+
+// locateJSONPointer finds the source defining the value at the pointer, and
+// the line it is defined at. If no source defines the value itself, the
+// closest parent is used. Later sources take precedence, as they do when
+// merging.
+func locateJSONPointer(sources []ProjectConfigSource, pointer string) (string, int) {
+	var (
+		name      string
+		line      int
+		bestDepth = -1
+	)
+	for _, s := range sources {
+		if s.Prefix != "" && pointer != s.Prefix && !strings.HasPrefix(pointer, s.Prefix+"/") {
+			continue
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(s.Content, &doc); err != nil || len(doc.Content) == 0 {
+			continue
+		}
+		depth, l := lookupYAMLNode(doc.Content[0], splitJSONPointer(strings.TrimPrefix(pointer, s.Prefix)))
+		if depth == 0 {
+			continue
+		}
+		// Count the prefix, so that files with and without one compare.
+		if s.Prefix != "" {
+			depth += len(splitJSONPointer(s.Prefix))
+		}
+		if depth >= bestDepth {
+			name, line, bestDepth = s.Name, l, depth
+		}
+	}
+	return name, line
+}
+
+// lookupYAMLNode walks the node along the path, and returns how many parts of
+// the path were found and the line of the last one.
+func lookupYAMLNode(node *yaml.Node, path []string) (int, int) {
+	depth, line := 0, node.Line
+	for _, part := range path {
+		if part == "" {
+			continue
+		}
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == part {
+					line = node.Content[i].Line
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if i, err := strconv.Atoi(part); err == nil && i >= 0 && i < len(node.Content) {
+				next = node.Content[i]
+				line = next.Line
+			}
+		}
+		if next == nil {
+			return depth, line
+		}
+		node = next
+		depth++
+	}
+	return depth, line
+}
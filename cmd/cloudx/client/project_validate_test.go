@@ -0,0 +1,110 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/client-go"
+)
+
+func TestValidateProjectConfig(t *testing.T) {
+	schemas, err := compileProjectConfigSchemas([]projectConfigSchema{{
+		Pointer: "/services/identity/config",
+		ID:      "ory://test-config",
+		Schema: []byte(`{
+  "type": "object",
+  "required": ["dsn"],
+  "additionalProperties": false,
+  "properties": {
+    "dsn": {"type": "string"},
+    "selfservice": {
+      "type": "object",
+      "properties": {
+        "methods": {
+          "type": "object",
+          "properties": {
+            "totp": {"type": "object", "properties": {"enabled": {"type": "boolean"}}}
+          }
+        }
+      }
+    },
+    "session": {"type": "object", "properties": {"lifespan": {"type": "string", "pattern": "^[0-9]+(ns|us|ms|s|m|h)$"}}}
+  }
+}`),
+	}})
+	require.NoError(t, err)
+
+	project := []byte(`name: Example
+services:
+  identity:
+    config:
+      selfservice:
+        methods:
+          totp:
+            enabled: "yes"
+`)
+	identity := []byte(`{
+  "session": {
+    "lifespan": "one day"
+  },
+  "unknown": true
+}`)
+	sources := []ProjectConfigSource{
+		{Name: "project.yaml", Content: project},
+		{Name: "identity.json", Prefix: "/services/identity/config", Content: identity},
+	}
+
+	configs := make([]json.RawMessage, len(sources))
+	for k, s := range sources {
+		configs[k], err = parseFile(s.Name, s.Content)
+		require.NoError(t, err)
+	}
+	configs[1], err = sjson.SetRawBytes([]byte("{}"), "services.identity.config", configs[1])
+	require.NoError(t, err)
+	config, err := mergeProjectConfigs(configs)
+	require.NoError(t, err)
+
+	err = validateProjectConfig(config, schemas, sources)
+	var verr *ProjectConfigValidationError
+	require.True(t, errors.As(err, &verr), "%+v", err)
+
+	byPointer := map[string]ProjectConfigViolation{}
+	for _, v := range verr.Violations {
+		byPointer[v.Pointer] = v
+	}
+	require.Len(t, byPointer, 3, "%+v", verr.Violations)
+
+	v := byPointer["/services/identity/config/selfservice/methods/totp/enabled"]
+	assert.Equal(t, "project.yaml:8", v.Location())
+
+	v = byPointer["/services/identity/config/session/lifespan"]
+	assert.Equal(t, "identity.json:3", v.Location())
+
+	v = byPointer["/services/identity/config"]
+	assert.Contains(t, v.Message, "unknown")
+	assert.NotContains(t, v.Message, "dsn", "keys required by the self-hosted services are managed by Ory Network")
+
+	assert.Contains(t, err.Error(), "found 3 problem(s)")
+
+	t.Run("case=valid", func(t *testing.T) {
+		assert.NoError(t, validateProjectConfig(map[string]any{"services": map[string]any{"identity": map[string]any{"config": map[string]any{}}}}, schemas, nil))
+	})
+
+	t.Run("case=patch source", func(t *testing.T) {
+		patches := []client.JsonPatch{
+			{Op: "replace", Path: "/services/identity/config/session"},
+			{Op: "add", Path: "/services/identity/config/session/lifespan"},
+			{Op: "remove", Path: "/services/identity/config/courier"},
+		}
+		assert.Equal(t, "add /services/identity/config/session/lifespan", patchSource(patches, "/services/identity/config/session/lifespan"))
+		assert.Equal(t, "", patchSource(patches, "/services/identity/config/selfservice"))
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -120,6 +121,41 @@ func (h *CommandHelper) ProjectAuthToken(ctx context.Context) (oauth2.TokenSourc
 	return config.TokenSource(ctx), CloudAPIsURL, nil
 }
 
+// newProjectAPIClient returns a client for the APIs of the project itself, such
+// as identities, OAuth2 clients, and relationships, as opposed to the Ory
+// Network console API.
+func (h *CommandHelper) newProjectAPIClient(ctx context.Context, slug string) (*cloud.APIClient, error) {
+	c, baseURL, err := h.newProjectHTTPClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conf := newSDKConfiguration(baseURL(slug + ".projects").String())
+	conf.HTTPClient = c
+	return cloud.NewAPIClient(conf), nil
+}
+
+// nextPageToken returns the page token of the next page announced in the Link
+// header of a paginated response, or an empty string on the last page.
+func nextPageToken(res *http.Response) string {
+	if res == nil {
+		return ""
+	}
+	for _, link := range res.Header.Values("Link") {
+		for _, part := range strings.Split(link, ",") {
+			target, params, ok := strings.Cut(strings.TrimSpace(part), ";")
+			if !ok || !strings.Contains(params, `rel="next"`) {
+				continue
+			}
+			u, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+			if err != nil {
+				return ""
+			}
+			return u.Query().Get("page_token")
+		}
+	}
+	return ""
+}
+
 func (h *CommandHelper) newProjectHTTPClient(ctx context.Context) (*http.Client, func(string) *url.URL, error) {
 	tokenSource, baseURL, err := h.ProjectAuthToken(ctx)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ory/cli/cmd/cloudx/workspace"
 
+	"github.com/ory/cli/cmd/cloudx/apikeys"
 	"github.com/ory/cli/cmd/cloudx/eventstreams"
 	"github.com/ory/cli/cmd/cloudx/oauth2"
 	"github.com/ory/cli/cmd/cloudx/organizations"
@@ -31,6 +32,7 @@ func NewCreateCmd() *cobra.Command {
 		organizations.NewCreateOrganizationCmd(),
 		eventstreams.NewCreateEventStreamCmd(),
 		workspace.NewCreateCmd(),
+		apikeys.NewCreateAPIKeyCmd(),
 	)
 
 	client.RegisterConfigFlag(cmd.PersistentFlags())
@@ -6,6 +6,7 @@ package cloudx
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/ory/cli/cmd/cloudx/apikeys"
 	"github.com/ory/cli/cmd/cloudx/eventstreams"
 	"github.com/ory/cli/cmd/cloudx/oauth2"
 	"github.com/ory/cli/cmd/cloudx/organizations"
@@ -30,6 +31,7 @@ func NewDeleteCmd() *cobra.Command {
 		relationtuples.NewDeleteCmd(),
 		organizations.NewDeleteOrganizationCmd(),
 		eventstreams.NewDeleteEventStream(),
+		apikeys.NewDeleteAPIKeyCmd(),
 	)
 
 	client.RegisterConfigFlag(cmd.PersistentFlags())
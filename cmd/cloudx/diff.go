@@ -0,0 +1,28 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudx
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/cli/cmd/cloudx/project"
+	"github.com/ory/x/cmdx"
+)
+
+func NewDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare resources",
+	}
+
+	cmd.AddCommand(
+		project.NewDiffProjectCmd(),
+	)
+
+	client.RegisterConfigFlag(cmd.PersistentFlags())
+	client.RegisterYesFlag(cmd.PersistentFlags())
+	cmdx.RegisterNoiseFlags(cmd.PersistentFlags())
+	return cmd
+}
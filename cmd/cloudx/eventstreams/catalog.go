@@ -0,0 +1,195 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package eventstreams
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/jsonschema/v3"
+)
+
+// eventTypesJSON is the catalog of the event types. The types and the
+// attributes in their data are the events of Ory Kratos, defined in
+// github.com/ory/kratos/x/events, without the attributes of the request that
+// all events share (see contextAttributes). The envelope is the same for all
+// types.
+//
+//go:embed event_types.json
+var eventTypesJSON []byte
+
+type eventType struct {
+	Type        string          `json:"type"`
+	Description string          `json:"description"`
+	Data        json.RawMessage `json:"data"`
+}
+
+var eventTypes = sync.OnceValue(func() []eventType {
+	var types []eventType
+	if err := json.Unmarshal(eventTypesJSON, &types); err != nil {
+		panic(fmt.Sprintf("the embedded event type catalog is invalid: %s", err))
+	}
+	return types
+})
+
+// contextAttributes are the attributes of the request an event occurred in,
+// which Ory adds to the data of all events if they are known. They are defined
+// in github.com/ory/x/otelx/semconv.
+var contextAttributes = map[string]any{
+	"ProjectID":          map[string]any{"type": "string", "format": "uuid", "description": "The ID of the project."},
+	"ClientIP":           map[string]any{"type": "string", "description": "The IP address of the client."},
+	"GeoLocationCity":    map[string]any{"type": "string", "description": "The city of the client."},
+	"GeoLocationCountry": map[string]any{"type": "string", "description": "The country of the client."},
+	"GeoLocationRegion":  map[string]any{"type": "string", "description": "The region of the client."},
+}
+
+// findEventType looks up the event type, ignoring case.
+func findEventType(name string) (*eventType, error) {
+	types := eventTypes()
+	i := slices.IndexFunc(types, func(t eventType) bool { return strings.EqualFold(t.Type, name) })
+	if i < 0 {
+		return nil, errors.Errorf("unknown event type %q, run `ory list event-types` to see all event types", name)
+	}
+	return &types[i], nil
+}
+
+func (t *eventType) schemaID() string {
+	return "ory://events/" + t.Type
+}
+
+// schema returns the JSON schema of the type's events.
+func (t *eventType) schema() (json.RawMessage, error) {
+	var data map[string]any
+	if err := json.Unmarshal(t.Data, &data); err != nil {
+		return nil, errors.Wrapf(err, "the data schema of %s is invalid", t.Type)
+	}
+	properties, _ := data["properties"].(map[string]any)
+	if properties == nil {
+		properties = make(map[string]any, len(contextAttributes))
+		data["properties"] = properties
+	}
+	for name, attribute := range contextAttributes {
+		if _, ok := properties[name]; !ok {
+			properties[name] = attribute
+		}
+	}
+
+	schema, err := json.Marshal(map[string]any{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"$id":         t.schemaID(),
+		"title":       t.Type,
+		"description": t.Description,
+		"type":        "object",
+		"required":    []string{"id", "type", "time", "project_id", "data"},
+		"properties": map[string]any{
+			"id":         map[string]any{"type": "string", "format": "uuid", "description": "The ID of the event."},
+			"type":       map[string]any{"const": t.Type, "description": "The type of the event."},
+			"time":       map[string]any{"type": "string", "format": "date-time", "description": "When the event occurred."},
+			"project_id": map[string]any{"type": "string", "format": "uuid", "description": "The ID of the project the event occurred in."},
+			"data":       data,
+		},
+	})
+	return schema, errors.WithStack(err)
+}
+
+// validate validates the event against the type's schema. Problems with the
+// event are returned as an *eventValidationError.
+func (t *eventType) validate(raw json.RawMessage) error {
+	schema, err := t.schema()
+	if err != nil {
+		return err
+	}
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource(t.schemaID(), bytes.NewReader(schema)); err != nil {
+		return errors.WithStack(err)
+	}
+	compiled, err := c.Compile(context.Background(), t.schemaID())
+	if err != nil {
+		return errors.Wrapf(err, "unable to compile the schema of %s", t.Type)
+	}
+
+	err = compiled.Validate(bytes.NewReader(raw))
+	var verr *jsonschema.ValidationError
+	if errors.As(err, &verr) {
+		return &eventValidationError{Type: t.Type, Violations: appendViolations(nil, verr)}
+	}
+	return errors.WithStack(err)
+}
+
+type (
+	// eventViolation is a single problem of an event.
+	eventViolation struct {
+		Pointer string `json:"pointer"`
+		Message string `json:"message"`
+	}
+
+	// eventValidationError lists all problems of an event.
+	eventValidationError struct {
+		Type       string           `json:"type"`
+		Violations []eventViolation `json:"violations"`
+	}
+)
+
+func (e *eventValidationError) Error() string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "the %s event is invalid, found %d problem(s):\n", e.Type, len(e.Violations))
+	for _, v := range e.Violations {
+		_, _ = fmt.Fprintf(&b, "\n  %s: %s", v.Pointer, v.Message)
+	}
+	return b.String()
+}
+
+func (e *eventValidationError) Header() []string {
+	return []string{"POINTER", "MESSAGE"}
+}
+
+func (e *eventValidationError) Table() [][]string {
+	rows := make([][]string, len(e.Violations))
+	for i, v := range e.Violations {
+		rows[i] = []string{v.Pointer, v.Message}
+	}
+	return rows
+}
+
+func (e *eventValidationError) Interface() interface{} {
+	return e
+}
+
+func (e *eventValidationError) Len() int {
+	return len(e.Violations)
+}
+
+// appendViolations flattens the validation error into its causes.
+func appendViolations(violations []eventViolation, err *jsonschema.ValidationError) []eventViolation {
+	if len(err.Causes) > 0 {
+		for _, cause := range err.Causes {
+			violations = appendViolations(violations, cause)
+		}
+		return violations
+	}
+
+	var pointer string
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(err.InstancePtr, "#"), "/"), "/") {
+		if part == "" {
+			continue
+		}
+		if unescaped, uerr := url.PathUnescape(part); uerr == nil {
+			part = unescaped
+		}
+		pointer += "/" + part
+	}
+	if pointer == "" {
+		pointer = "/"
+	}
+	return append(violations, eventViolation{Pointer: pointer, Message: err.Message})
+}
@@ -0,0 +1,143 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package eventstreams
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestEventCatalog(t *testing.T) {
+	t.Parallel()
+
+	t.Run("case=all schemas compile", func(t *testing.T) {
+		require.NotEmpty(t, eventTypes())
+		for _, et := range eventTypes() {
+			schema, err := et.schema()
+			require.NoError(t, err)
+			assert.Equal(t, et.Type, gjson.GetBytes(schema, "properties.type.const").String())
+			// Compiling is part of validating.
+			err = et.validate([]byte(`{}`))
+			var verr *eventValidationError
+			require.ErrorAs(t, err, &verr, "%s", et.Type)
+		}
+	})
+
+	t.Run("case=the sample event is valid", func(t *testing.T) {
+		ev, err := sampleEvent("4f4a5b5f-0a8a-4d0f-8d2a-0e6f4c1c1c1c", time.Now())
+		require.NoError(t, err)
+		raw, err := json.Marshal(ev)
+		require.NoError(t, err)
+
+		et, err := findEventType(sampleEventType)
+		require.NoError(t, err)
+		require.NoError(t, et.validate(raw))
+		assert.Equal(t, "4f4a5b5f-0a8a-4d0f-8d2a-0e6f4c1c1c1c", gjson.GetBytes(raw, "data.ProjectID").String())
+
+		parsed, err := parseEvent(raw)
+		require.NoError(t, err)
+		assert.Equal(t, ev.ID, parsed.ID)
+		assert.Contains(t, parsed.Header(), "DATA IDENTITYID")
+	})
+
+	t.Run("case=sample data is valid for all types", func(t *testing.T) {
+		for _, et := range eventTypes() {
+			data, err := et.sampleData("4f4a5b5f-0a8a-4d0f-8d2a-0e6f4c1c1c1c")
+			require.NoError(t, err)
+			raw, err := json.Marshal(&event{ID: "c5b7a4f4-9f8e-4b8e-8a8b-5f0f7d5c2b1a", Type: et.Type, Time: time.Now(), ProjectID: "4f4a5b5f-0a8a-4d0f-8d2a-0e6f4c1c1c1c", Data: data})
+			require.NoError(t, err)
+			assert.NoError(t, et.validate(raw), "%s", et.Type)
+		}
+	})
+
+	t.Run("case=events of Ory Kratos are valid", func(t *testing.T) {
+		// The events were recorded from the constructors in
+		// github.com/ory/kratos/x/events, one for each type of the catalog.
+		raw, err := os.ReadFile("fixtures/events.json")
+		require.NoError(t, err)
+		var events []json.RawMessage
+		require.NoError(t, json.Unmarshal(raw, &events))
+
+		var types []string
+		for _, raw := range events {
+			ev, err := parseEvent(raw)
+			require.NoError(t, err, "%s", raw)
+			types = append(types, ev.Type)
+		}
+		var catalog []string
+		for _, et := range eventTypes() {
+			catalog = append(catalog, et.Type)
+		}
+		assert.ElementsMatch(t, catalog, types)
+	})
+
+	t.Run("case=types are found ignoring case", func(t *testing.T) {
+		et, err := findEventType("sessionissued")
+		require.NoError(t, err)
+		assert.Equal(t, "SessionIssued", et.Type)
+
+		_, err = findEventType("Unknown")
+		assert.ErrorContains(t, err, "ory list event-types")
+	})
+
+	t.Run("case=reports all problems", func(t *testing.T) {
+		_, err := parseEvent([]byte(`{"id": "c5b7a4f4-9f8e-4b8e-8a8b-5f0f7d5c2b1a", "type": "LoginSucceeded", "time": "yesterday", "project_id": "4f4a5b5f-0a8a-4d0f-8d2a-0e6f4c1c1c1c", "data": {"IdentityID": "e4a0e2a4-6d62-4c9b-9a38-3a2f61e0f6e1", "LoginRequestedPrivilegedSession": "no"}}`))
+		var verr *eventValidationError
+		require.ErrorAs(t, err, &verr)
+		pointers := make([]string, len(verr.Violations))
+		for i, v := range verr.Violations {
+			pointers[i] = v.Pointer
+		}
+		assert.ElementsMatch(t, []string{"/time", "/data", "/data/LoginRequestedPrivilegedSession"}, pointers, "%+v", verr.Violations)
+
+		_, err = parseEvent([]byte(`{"id": "x"}`))
+		assert.EqualError(t, err, `the event does not have a "type"`)
+		_, err = parseEvent([]byte(`{`))
+		assert.EqualError(t, err, "the event is not valid JSON")
+	})
+}
+
+func TestParseEventCmd(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "payload.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{
+  "id": "c5b7a4f4-9f8e-4b8e-8a8b-5f0f7d5c2b1a",
+  "type": "SessionIssued",
+  "time": "2026-10-17T12:00:00Z",
+  "project_id": "4f4a5b5f-0a8a-4d0f-8d2a-0e6f4c1c1c1c",
+  "data": {
+    "IdentityID": "e4a0e2a4-6d62-4c9b-9a38-3a2f61e0f6e1",
+    "SessionID": "0b0f3f5e-3f57-4a43-a1a4-7a5d3c9b2e10",
+    "SessionAAL": "aal2"
+  }
+}`), 0600))
+
+	run := func(args ...string) (string, error) {
+		cmd := NewParseEventCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("-f", file)
+	require.NoError(t, err)
+	assert.Contains(t, out, "SessionIssued")
+	assert.Regexp(t, `DATA SESSIONAAL\s+aal2`, out)
+
+	out, err = run("-f", file, "--format", "json")
+	require.NoError(t, err)
+	assert.Equal(t, "0b0f3f5e-3f57-4a43-a1a4-7a5d3c9b2e10", gjson.Get(out, "data.SessionID").String(), out)
+}
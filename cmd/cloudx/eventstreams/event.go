@@ -0,0 +1,75 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package eventstreams
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+// event is an event as delivered to an event stream's destination.
+type event struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Time      time.Time       `json:"time"`
+	ProjectID string          `json:"project_id"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// sampleEventType is the type of the events `ory test event-stream` sends.
+const sampleEventType = "IdentityCreated"
+
+// sampleEvent returns an event of the sample type with made-up data, for
+// testing destinations.
+func sampleEvent(projectID string, now time.Time) (*event, error) {
+	t, err := findEventType(sampleEventType)
+	if err != nil {
+		return nil, err
+	}
+	data, err := t.sampleData(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return &event{
+		ID:        uuid.Must(uuid.NewV4()).String(),
+		Type:      t.Type,
+		Time:      now.UTC(),
+		ProjectID: projectID,
+		Data:      data,
+	}, nil
+}
+
+// sampleData returns made-up values for the required attributes of the type,
+// and the project ID.
+func (t *eventType) sampleData(projectID string) (json.RawMessage, error) {
+	var schema struct {
+		Required   []string `json:"required"`
+		Properties map[string]struct {
+			Type   string `json:"type"`
+			Format string `json:"format"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(t.Data, &schema); err != nil {
+		return nil, errors.Wrapf(err, "the data schema of %s is invalid", t.Type)
+	}
+
+	data := map[string]any{"ProjectID": projectID}
+	for _, name := range schema.Required {
+		switch p := schema.Properties[name]; {
+		case p.Format == "uuid":
+			data[name] = uuid.Must(uuid.NewV4()).String()
+		case p.Type == "boolean":
+			data[name] = false
+		case p.Type == "integer":
+			data[name] = 0
+		default:
+			data[name] = ""
+		}
+	}
+	raw, err := json.Marshal(data)
+	return raw, errors.WithStack(err)
+}
@@ -0,0 +1,141 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package eventstreams
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/graceful"
+	"github.com/ory/x/flagx"
+	"github.com/ory/x/tlsx"
+)
+
+const (
+	flagPort = "port"
+	flagHTTP = "http"
+)
+
+// maxEventSize limits the payloads the receiver accepts.
+const maxEventSize = 1 << 20
+
+func NewListenEventsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events [--port=9000]",
+		Args:  cobra.NoArgs,
+		Short: "Receive events locally and print them",
+		Long: `Run an HTTPS server that receives the events of HTTPS event streams and pretty-prints them.
+
+Event streams only send to HTTPS endpoints, so the server uses a self-signed certificate created on start.
+To receive events from Ory, make the server reachable using a tunnel and create an event stream for the tunnel's URL.
+Use --http if the tunnel terminates TLS itself.
+
+Send a sample event using
+
+	ory test event-stream <id> --skip-tls-verify`,
+		Example: `$ ory listen events --port 9000
+$ ory create event-stream --type https --https-endpoint https://<tunnel-host>/`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stdErr := cmd.ErrOrStderr()
+			server := graceful.WithDefaults(&http.Server{
+				Addr:    fmt.Sprintf(":%d", flagx.MustGetInt(cmd, flagPort)),
+				Handler: &eventReceiver{out: cmd.OutOrStdout(), stdErr: stdErr},
+			})
+
+			scheme := "http"
+			if !flagx.MustGetBool(cmd, flagHTTP) {
+				scheme = "https"
+				key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				if err != nil {
+					return err
+				}
+				cert, err := tlsx.CreateSelfSignedTLSCertificate(key)
+				if err != nil {
+					return err
+				}
+				server.TLSConfig.Certificates = []tls.Certificate{*cert}
+				_, _ = fmt.Fprintf(stdErr, "The server uses a self-signed certificate with the SHA-256 fingerprint\n\n\t%s\n\n", fingerprint(cert.Certificate[0]))
+			}
+			_, _ = fmt.Fprintf(stdErr, "Listening for events on %s://localhost%s\n\n", scheme, server.Addr)
+
+			if err := graceful.Graceful(func() error {
+				if scheme == "https" {
+					return server.ListenAndServeTLS("", "")
+				}
+				return server.ListenAndServe()
+			}, server.Shutdown); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Int(flagPort, 9000, "The port to listen on.")
+	cmd.Flags().Bool(flagHTTP, false, "Serve plain HTTP instead of HTTPS.")
+	return cmd
+}
+
+func fingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	hex := make([]string, len(sum))
+	for i, b := range sum {
+		hex[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hex, ":")
+}
+
+// eventReceiver prints the events posted to it. The payloads are printed to
+// out, everything else to stdErr.
+type eventReceiver struct {
+	mu          sync.Mutex
+	out, stdErr io.Writer
+}
+
+func (rc *eventReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "Events must be sent using POST.", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxEventSize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Unable to read the event: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	summary := []string{time.Now().UTC().Format(time.RFC3339), r.Method, r.URL.Path}
+	for _, field := range []string{"type", "id"} {
+		if v := gjson.GetBytes(body, field); v.Type == gjson.String {
+			summary = append(summary, v.String())
+		}
+	}
+	_, _ = fmt.Fprintf(rc.stdErr, "Received %s\n", strings.Join(summary, " "))
+
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, body, "", "  "); err != nil {
+		_, _ = fmt.Fprintf(rc.stdErr, "The payload is not valid JSON: %s\n", err)
+		_, _ = fmt.Fprintf(rc.out, "%s\n", body)
+		http.Error(w, fmt.Sprintf("The event is not valid JSON: %s", err), http.StatusBadRequest)
+		return
+	}
+	_, _ = fmt.Fprintf(rc.out, "%s\n", pretty.Bytes())
+	w.WriteHeader(http.StatusNoContent)
+}
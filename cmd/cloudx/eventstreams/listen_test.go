@@ -0,0 +1,76 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package eventstreams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiveEvents(t *testing.T) {
+	t.Parallel()
+
+	var out, stdErr bytes.Buffer
+	receiver := httptest.NewUnstartedServer(&eventReceiver{out: &out, stdErr: &stdErr})
+	// The client rejecting the certificate is logged otherwise.
+	receiver.Config.ErrorLog = log.New(io.Discard, "", 0)
+	receiver.StartTLS()
+	t.Cleanup(receiver.Close)
+
+	ev, err := sampleEvent("project-id", time.Now())
+	require.NoError(t, err)
+
+	t.Run("case=delivers the sample event", func(t *testing.T) {
+		d := &delivery{Destination: receiver.URL + "/webhook", Event: ev}
+		require.NoError(t, deliver(context.Background(), receiver.Client(), d))
+		assert.True(t, d.Delivered)
+		assert.Equal(t, http.StatusNoContent, d.Status)
+
+		pretty, err := json.MarshalIndent(ev, "", "  ")
+		require.NoError(t, err)
+		assert.Equal(t, string(pretty)+"\n", out.String())
+		assert.Contains(t, stdErr.String(), "POST /webhook "+sampleEventType+" "+ev.ID)
+	})
+
+	t.Run("case=reports rejected events", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "unable to parse the event", http.StatusInternalServerError)
+		}))
+		t.Cleanup(failing.Close)
+
+		d := &delivery{Destination: failing.URL, Event: ev}
+		err := deliver(context.Background(), failing.Client(), d)
+		assert.EqualError(t, err, "the endpoint answered with status 500: unable to parse the event")
+		assert.False(t, d.Delivered)
+		assert.Equal(t, http.StatusInternalServerError, d.Status)
+	})
+
+	t.Run("case=rejects invalid payloads", func(t *testing.T) {
+		res, err := receiver.Client().Post(receiver.URL, "application/json", strings.NewReader("{"))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+		res, err = receiver.Client().Get(receiver.URL)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+	})
+
+	t.Run("case=verifies the certificate by default", func(t *testing.T) {
+		d := &delivery{Destination: receiver.URL, Event: ev}
+		assert.ErrorContains(t, deliver(context.Background(), &http.Client{}, d), "certificate")
+	})
+}
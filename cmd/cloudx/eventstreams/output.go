@@ -4,9 +4,16 @@
 package eventstreams
 
 import (
+	"bytes"
 	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	client "github.com/ory/client-go"
+	"github.com/ory/x/cmdx"
 )
 
 type (
@@ -63,3 +70,92 @@ func (o outputList) Len() int {
 func (o outputList) MarshalJSON() ([]byte, error) {
 	return json.Marshal(o.EventStreams)
 }
+
+type outputDelivery delivery
+
+func (*outputDelivery) Header() []string {
+	return []string{"STREAM", "DESTINATION", "EVENT", "TYPE", "DELIVERED", "STATUS", "DURATION"}
+}
+
+func (d *outputDelivery) Columns() []string {
+	status, duration := cmdx.None, cmdx.None
+	if d.Status != 0 {
+		status = strconv.Itoa(d.Status)
+	}
+	if d.Duration != 0 {
+		duration = d.Duration.String()
+	}
+	return []string{d.StreamID, d.Destination, d.Event.ID, d.Event.Type, strconv.FormatBool(d.Delivered), status, duration}
+}
+
+func (d *outputDelivery) Interface() interface{} {
+	return (*delivery)(d)
+}
+
+type outputEventTypes []eventType
+
+func (outputEventTypes) Header() []string {
+	return []string{"TYPE", "DESCRIPTION"}
+}
+
+func (o outputEventTypes) Table() [][]string {
+	rows := make([][]string, len(o))
+	for i, t := range o {
+		rows[i] = []string{t.Type, t.Description}
+	}
+	return rows
+}
+
+func (o outputEventTypes) Interface() interface{} {
+	return o
+}
+
+func (o outputEventTypes) Len() int {
+	return len(o)
+}
+
+type outputSchema json.RawMessage
+
+func (o outputSchema) String() string {
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, o, "", "  "); err != nil {
+		return string(o)
+	}
+	return pretty.String() + "\n"
+}
+
+func (o outputSchema) MarshalJSON() ([]byte, error) {
+	return o, nil
+}
+
+// outputParsedEvent is an event with its data decoded, to print each property
+// of the data in its own row.
+type outputParsedEvent struct {
+	event
+	data map[string]any
+}
+
+func (o *outputParsedEvent) Header() []string {
+	header := []string{"ID", "TYPE", "TIME", "PROJECT ID"}
+	for _, k := range slices.Sorted(maps.Keys(o.data)) {
+		header = append(header, "DATA "+strings.ToUpper(k))
+	}
+	return header
+}
+
+func (o *outputParsedEvent) Columns() []string {
+	columns := []string{o.ID, o.Type, o.Time.Format(time.RFC3339), o.ProjectID}
+	for _, k := range slices.Sorted(maps.Keys(o.data)) {
+		if s, ok := o.data[k].(string); ok {
+			columns = append(columns, s)
+			continue
+		}
+		v, _ := json.Marshal(o.data[k])
+		columns = append(columns, string(v))
+	}
+	return columns
+}
+
+func (o *outputParsedEvent) Interface() interface{} {
+	return &o.event
+}
@@ -0,0 +1,96 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package eventstreams
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+func NewParseEventCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "event -f <payload.json>",
+		Args:  cobra.NoArgs,
+		Short: "Validate and print an event",
+		Long: `Validate an event, as sent to event streams, against the JSON schema of its type and print it.
+
+Use "-f -" to read the event from standard input. If the event is invalid, its problems are printed instead.`,
+		Example: `$ ory parse event -f payload.json
+$ ory parse event -f payload.json --format json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			file := flagx.MustGetString(cmd, "file")
+			if file == "" {
+				return errors.New("the event must be set using --file")
+			}
+			raw, err := readEvent(cmd, file)
+			if err != nil {
+				return err
+			}
+
+			ev, err := parseEvent(raw)
+			var verr *eventValidationError
+			if errors.As(err, &verr) {
+				cmdx.PrintTable(cmd, verr)
+				return cmdx.FailSilently(cmd)
+			} else if err != nil {
+				return err
+			}
+
+			cmdx.PrintRow(cmd, ev)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("file", "f", "", `The file containing the event, or "-" for standard input.`)
+	cmdx.RegisterFormatFlags(cmd.Flags())
+	return cmd
+}
+
+func readEvent(cmd *cobra.Command, file string) ([]byte, error) {
+	if file == "-" {
+		return io.ReadAll(cmd.InOrStdin())
+	}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the event: %w", err)
+	}
+	return raw, nil
+}
+
+// parseEvent validates the event against the schema of its type and decodes
+// it.
+func parseEvent(raw []byte) (*outputParsedEvent, error) {
+	if !json.Valid(raw) {
+		return nil, errors.New("the event is not valid JSON")
+	}
+	typ := gjson.GetBytes(raw, "type")
+	if typ.Type != gjson.String {
+		return nil, errors.New(`the event does not have a "type"`)
+	}
+	t, err := findEventType(typ.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := t.validate(raw); err != nil {
+		return nil, err
+	}
+
+	var ev outputParsedEvent
+	if err := json.Unmarshal(raw, &ev.event); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(ev.Data, &ev.data); err != nil {
+		return nil, err
+	}
+	return &ev, nil
+}
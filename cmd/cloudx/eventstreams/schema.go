@@ -0,0 +1,54 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package eventstreams
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ory/x/cmdx"
+)
+
+func NewListEventTypesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "event-types",
+		Args:  cobra.NoArgs,
+		Short: "List the types of events sent to event streams",
+		Long:  "List the types of events sent to event streams. Use `ory get event-schema <type>` to get the JSON schema of a type's events.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdx.PrintTable(cmd, outputEventTypes(eventTypes()))
+			return nil
+		},
+	}
+
+	cmdx.RegisterFormatFlags(cmd.Flags())
+	return cmd
+}
+
+func NewGetEventSchemaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "event-schema <type>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Get the JSON schema of an event type",
+		Long: `Get the JSON schema of the events of the given type, as sent to event streams.
+
+All events share the same envelope, the schema of the "data" property depends on the type. The data holds the attributes of the event, as defined in github.com/ory/kratos/x/events, and the project, client IP, and geolocation of the request if they are known.
+Use ` + "`ory parse event`" + ` to validate a captured event against its schema.`,
+		Example: "$ ory get event-schema IdentityCreated",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			t, err := findEventType(args[0])
+			if err != nil {
+				return err
+			}
+			schema, err := t.schema()
+			if err != nil {
+				return err
+			}
+			cmdx.PrintJSONAble(cmd, outputSchema(schema))
+			return nil
+		},
+	}
+
+	cmdx.RegisterFormatFlags(cmd.Flags())
+	return cmd
+}
@@ -0,0 +1,145 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package eventstreams
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	cloud "github.com/ory/client-go"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+// deliveryTimeout bounds sending the sample event to the destination.
+const deliveryTimeout = 10 * time.Second
+
+// delivery is the result of sending a sample event to an event stream.
+type delivery struct {
+	StreamID string `json:"stream_id"`
+	// Destination is the HTTPS endpoint or the SNS topic of the stream.
+	Destination string `json:"destination"`
+	Delivered   bool   `json:"delivered"`
+	// Status is the HTTP status code the destination answered with.
+	Status   int           `json:"status,omitempty"`
+	Duration time.Duration `json:"duration,omitempty"`
+	Event    *event        `json:"event"`
+}
+
+func NewTestEventStreamCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "event-stream <id> [--project=PROJECT_ID]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Send a sample event to the event stream with the given ID",
+		Long: fmt.Sprintf(`Send a sample %q event to the destination of the event stream with the given ID.
+
+For HTTPS streams, the event is sent from this machine to the stream's endpoint, the way Ory sends events.
+The command fails unless the endpoint answers with a 2xx status code.
+The Ory CLI can not publish to AWS SNS topics, so for SNS streams the event is only printed.
+
+Use `+"`ory listen events`"+` to receive events locally.`, sampleEventType),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			projectID, err := h.ProjectID()
+			if err != nil {
+				return err
+			}
+
+			streams, err := h.ListEventStreams(ctx, projectID)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			var stream *cloud.EventStream
+			for _, s := range streams.EventStreams {
+				if s.GetId() == args[0] {
+					stream = &s
+					break
+				}
+			}
+			if stream == nil {
+				return fmt.Errorf("event stream %s does not exist", args[0])
+			}
+			if stream.GetStatus() == StatusPaused {
+				_, _ = fmt.Fprintln(h.VerboseErrWriter, "The event stream is paused, Ory does not send any events to it until it is resumed.")
+			}
+
+			ev, err := sampleEvent(projectID, time.Now())
+			if err != nil {
+				return err
+			}
+
+			d := &delivery{StreamID: stream.GetId(), Event: ev}
+			switch stream.GetType() {
+			case "https":
+				d.Destination = stream.GetHttpsEndpoint()
+				hc := &http.Client{Timeout: deliveryTimeout}
+				if flagx.MustGetBool(cmd, cmdx.FlagSkipTLSVerify) {
+					hc.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+				}
+				if err := deliver(ctx, hc, d); err != nil {
+					cmdx.PrintRow(cmd, (*outputDelivery)(d))
+					_, _ = fmt.Fprintf(h.VerboseErrWriter, "Unable to deliver the event: %s\n", err)
+					return cmdx.FailSilently(cmd)
+				}
+				_, _ = fmt.Fprintln(h.VerboseErrWriter, "Event delivered successfully!")
+			default:
+				d.Destination = stream.GetTopicArn()
+				_, _ = fmt.Fprintf(h.VerboseErrWriter, "The Ory CLI can not publish to %s streams. This is the event Ory would send.\n", stream.GetType())
+			}
+
+			cmdx.PrintRow(cmd, (*outputDelivery)(d))
+			return nil
+		},
+	}
+
+	client.RegisterProjectFlag(cmd.Flags())
+	client.RegisterWorkspaceFlag(cmd.Flags())
+	cmdx.RegisterFormatFlags(cmd.Flags())
+	cmd.Flags().Bool(cmdx.FlagSkipTLSVerify, false, "Do not verify the endpoint's TLS certificate, for example to send to `ory listen events`. Do not use in production!")
+	return cmd
+}
+
+// deliver posts the event to the HTTPS endpoint of the delivery.
+func deliver(ctx context.Context, hc *http.Client, d *delivery) error {
+	body, err := json.Marshal(d.Event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", d.Destination, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	res, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	d.Duration = time.Since(start).Round(time.Millisecond)
+	d.Status = res.StatusCode
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		answer, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("the endpoint answered with status %d: %s", res.StatusCode, bytes.TrimSpace(answer))
+	}
+	d.Delivered = true
+	return nil
+}
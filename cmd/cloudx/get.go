@@ -9,6 +9,7 @@ import (
 	"github.com/ory/x/cmdx"
 
 	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/cli/cmd/cloudx/eventstreams"
 	"github.com/ory/cli/cmd/cloudx/identity"
 	"github.com/ory/cli/cmd/cloudx/oauth2"
 	"github.com/ory/cli/cmd/cloudx/project"
@@ -31,6 +32,7 @@ func NewGetCmd() *cobra.Command {
 		identity.NewGetIdentityCmd(),
 		oauth2.NewGetOAuth2Client(),
 		oauth2.NewGetJWK(),
+		eventstreams.NewGetEventSchemaCmd(),
 	)
 
 	client.RegisterConfigFlag(cmd.PersistentFlags())
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ory/cli/cmd/cloudx/client"
 	"github.com/ory/cli/cmd/cloudx/identity"
+	"github.com/ory/cli/cmd/cloudx/project"
 	"github.com/ory/x/cmdx"
 )
 
@@ -23,6 +24,7 @@ func NewImportCmd() *cobra.Command {
 		identity.NewImportIdentityCmd(),
 		oauth2.NewImportOAuth2Client(),
 		oauth2.NewImportJWK(),
+		project.NewImportProjectBundleCmd(),
 	)
 
 	client.RegisterConfigFlag(cmd.PersistentFlags())
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ory/cli/cmd/cloudx/workspace"
 
+	"github.com/ory/cli/cmd/cloudx/apikeys"
 	"github.com/ory/cli/cmd/cloudx/eventstreams"
 	"github.com/ory/cli/cmd/cloudx/identity"
 	"github.com/ory/cli/cmd/cloudx/oauth2"
@@ -33,7 +34,10 @@ func NewListCmd() *cobra.Command {
 		oauth2.NewListOAuth2Clients(),
 		relationtuples.NewListCmd(),
 		eventstreams.NewListEventStreamsCmd(),
+		eventstreams.NewListEventTypesCmd(),
 		workspace.NewListCmd(),
+		apikeys.NewListAPIKeysCmd(),
+		NewListProfilesCmd(),
 	)
 
 	client.RegisterConfigFlag(cmd.PersistentFlags())
@@ -0,0 +1,25 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudx
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/eventstreams"
+	"github.com/ory/x/cmdx"
+)
+
+func NewListenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "listen",
+		Short: "Receive data from Ory Network locally",
+	}
+
+	cmd.AddCommand(
+		eventstreams.NewListenEventsCmd(),
+	)
+
+	cmdx.RegisterNoiseFlags(cmd.PersistentFlags())
+	return cmd
+}
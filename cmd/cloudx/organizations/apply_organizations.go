@@ -0,0 +1,435 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package organizations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	cloud "github.com/ory/client-go"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+const (
+	flagPrune  = "prune"
+	flagDryRun = "dry-run"
+)
+
+const (
+	organizationCreate = "create"
+	organizationUpdate = "update"
+	organizationDelete = "delete"
+)
+
+type (
+	// desiredOrganization is an organization as described in the file.
+	// Organizations without an ID are matched by their label.
+	desiredOrganization struct {
+		ID      string   `json:"id,omitempty"`
+		Label   string   `json:"label"`
+		Domains []string `json:"domains"`
+	}
+
+	organizationsFile struct {
+		Organizations []desiredOrganization `json:"organizations"`
+	}
+
+	// organizationChange is a single change of an organizations plan.
+	organizationChange struct {
+		Op          string   `json:"op"`
+		ID          string   `json:"id,omitempty"`
+		Label       string   `json:"label"`
+		Domains     []string `json:"domains"`
+		FromLabel   string   `json:"from_label,omitempty"`
+		FromDomains []string `json:"from_domains,omitempty"`
+	}
+
+	// organizationsPlan are the changes that make the organizations of a
+	// project match the file, in the order they are applied.
+	organizationsPlan struct {
+		ProjectID string               `json:"project_id"`
+		Changes   []organizationChange `json:"changes"`
+		Unchanged int                  `json:"unchanged"`
+		// Unmanaged counts the organizations missing from the file, which are
+		// kept unless pruning.
+		Unmanaged int `json:"unmanaged"`
+	}
+)
+
+func NewApplyOrganizationsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "organizations -f <orgs.yaml> [--project=PROJECT_ID] [--prune]",
+		Args:  cobra.NoArgs,
+		Short: "Apply a set of organizations",
+		Long: `Compares the organizations in the file(s) with the organizations of the Ory Network
+project, prints the changes, and after confirmation creates and updates the
+organizations to match. With --prune, organizations missing from the file are
+deleted. Use --dry-run to only print the changes.
+
+Organizations are matched by their ID if the file has one, and by their label
+otherwise. To change the label of an organization, set its ID in the file.
+A domain can only belong to a single organization, which is checked before
+anything is changed.`,
+		Example: `$ cat orgs.yaml
+organizations:
+  - label: Acme
+    domains: [acme.com, acme.io]
+  - id: 7b3c4a1e-5f7e-4c8b-9f1d-2a6b8c0d4e5f
+    label: Globex Inc.
+    domains: [globex.com]
+
+$ ory apply organizations -f orgs.yaml
+
+Organizations of project ecaaa3cb-0730-4ee8-a6df-9553cdfeef89 will be changed as follows:
+
+  + Acme: domains [acme.com, acme.io]
+  ~ Globex Inc. (7b3c4a1e-5f7e-4c8b-9f1d-2a6b8c0d4e5f): label "Globex" -> "Globex Inc."
+
+Plan: 1 to create, 1 to update, 0 to delete.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			files := flagx.MustGetStringSlice(cmd, "file")
+			if len(files) == 0 {
+				return errors.New("at least one file must be set using --file")
+			}
+			desired, err := readDesiredOrganizations(files)
+			if err != nil {
+				return err
+			}
+
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+			projectID, err := h.ProjectID()
+			if err != nil {
+				return err
+			}
+			existing, err := h.ListOrganizations(cmd.Context(), projectID)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+
+			plan, err := planOrganizations(projectID, desired, existing.Organizations, flagx.MustGetBool(cmd, flagPrune))
+			if err != nil {
+				return err
+			}
+			if flagx.MustGetBool(cmd, flagDryRun) {
+				cmdx.PrintJSONAble(cmd, plan)
+				return nil
+			}
+
+			_, _ = fmt.Fprint(h.VerboseErrWriter, plan.String())
+			if plan.Empty() {
+				return nil
+			}
+			if ok, err := h.Confirm("Do you want to apply these changes?"); err != nil {
+				return err
+			} else if !ok {
+				_, _ = fmt.Fprintln(h.VerboseErrWriter, "Apply cancelled.")
+				return cmdx.FailSilently(cmd)
+			}
+
+			if err := applyOrganizations(cmd.Context(), h, plan); err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			_, _ = fmt.Fprintln(h.VerboseErrWriter, "Organizations applied successfully!")
+
+			organizations, err := h.ListOrganizations(cmd.Context(), projectID)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			cmdx.PrintTable(cmd, &outputOrganizations{organizations})
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceP("file", "f", nil, "File(s) (file://orgs.json, https://example.org/orgs.yaml, ...) listing the desired organizations")
+	cmd.Flags().Bool(flagPrune, false, "Delete the organizations missing from the file(s).")
+	cmd.Flags().Bool(flagDryRun, false, "Only print the changes.")
+	client.RegisterProjectFlag(cmd.Flags())
+	client.RegisterWorkspaceFlag(cmd.Flags())
+	cmdx.RegisterFormatFlags(cmd.Flags())
+	return cmd
+}
+
+// readDesiredOrganizations reads the organizations from the files, and
+// validates them. The files list the organizations below an
+// `organizations` key.
+func readDesiredOrganizations(files []string) ([]desiredOrganization, error) {
+	contents, err := client.ReadAndParseFiles(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var desired []desiredOrganization
+	for i, raw := range contents {
+		var f organizationsFile
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+			return nil, errors.Errorf("unable to decode the organizations in %s: %s", files[i], err)
+		}
+		desired = append(desired, f.Organizations...)
+	}
+	return desired, validateDesiredOrganizations(desired)
+}
+
+func normalizeDomain(d string) string {
+	return strings.ToLower(strings.TrimSpace(d))
+}
+
+// validateDesiredOrganizations normalizes the domains, and makes sure that the
+// organizations can be told apart and that no domain is used twice.
+func validateDesiredOrganizations(desired []desiredOrganization) error {
+	var (
+		ids     = map[string]string{}
+		labels  = map[string]bool{}
+		domains = map[string]string{}
+		errs    []string
+	)
+	for i := range desired {
+		o := &desired[i]
+		o.Label = strings.TrimSpace(o.Label)
+		if o.Label == "" {
+			errs = append(errs, fmt.Sprintf("organization #%d does not have a label", i+1))
+			continue
+		}
+
+		if o.ID != "" {
+			if other, ok := ids[o.ID]; ok {
+				errs = append(errs, fmt.Sprintf("organizations %q and %q have the same ID %s", other, o.Label, o.ID))
+			}
+			ids[o.ID] = o.Label
+		} else {
+			if labels[o.Label] {
+				errs = append(errs, fmt.Sprintf("there is more than one organization labelled %q, set their IDs to tell them apart", o.Label))
+			}
+			labels[o.Label] = true
+		}
+
+		normalized := make([]string, 0, len(o.Domains))
+		for _, d := range o.Domains {
+			d = normalizeDomain(d)
+			switch {
+			case d == "" || strings.ContainsAny(d, "/:@ "):
+				errs = append(errs, fmt.Sprintf("organization %q has an invalid domain %q, domains must not have a scheme, port, or path", o.Label, d))
+				continue
+			case slices.Contains(normalized, d):
+				continue
+			}
+			if other, ok := domains[d]; ok {
+				errs = append(errs, fmt.Sprintf("domain %s is used by organizations %q and %q, but can only belong to one", d, other, o.Label))
+			}
+			domains[d] = o.Label
+			normalized = append(normalized, d)
+		}
+		slices.Sort(normalized)
+		o.Domains = normalized
+	}
+
+	if len(errs) > 0 {
+		return errors.Errorf("the organizations are invalid:\n\n  %s", strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+// planOrganizations computes the changes making the existing organizations
+// match the desired ones. The desired organizations must be validated.
+func planOrganizations(projectID string, desired []desiredOrganization, existing []cloud.Organization, prune bool) (*organizationsPlan, error) {
+	plan := &organizationsPlan{ProjectID: projectID, Changes: []organizationChange{}}
+
+	byID := make(map[string]*cloud.Organization, len(existing))
+	byLabel := make(map[string][]*cloud.Organization, len(existing))
+	for i := range existing {
+		e := &existing[i]
+		byID[e.Id] = e
+		byLabel[e.Label] = append(byLabel[e.Label], e)
+	}
+
+	var (
+		matched = map[string]bool{}
+		changes []organizationChange
+	)
+	for _, o := range desired {
+		var e *cloud.Organization
+		if o.ID != "" {
+			if e = byID[o.ID]; e == nil {
+				return nil, errors.Errorf("organization %q has the ID %s, but the project has no organization with that ID", o.Label, o.ID)
+			}
+		} else if candidates := byLabel[o.Label]; len(candidates) > 1 {
+			return nil, errors.Errorf("the project has %d organizations labelled %q, set the ID in the file to choose one", len(candidates), o.Label)
+		} else if len(candidates) == 1 {
+			e = candidates[0]
+		}
+
+		if e == nil {
+			changes = append(changes, organizationChange{Op: organizationCreate, Label: o.Label, Domains: o.Domains})
+			continue
+		}
+		if matched[e.Id] {
+			return nil, errors.Errorf("organization %s (%s) is matched by more than one organization in the file", e.Label, e.Id)
+		}
+		matched[e.Id] = true
+
+		current := existingDomains(e)
+		if e.Label == o.Label && slices.Equal(current, o.Domains) {
+			plan.Unchanged++
+			continue
+		}
+		c := organizationChange{Op: organizationUpdate, ID: e.Id, Label: o.Label, Domains: o.Domains}
+		if e.Label != o.Label {
+			c.FromLabel = e.Label
+		}
+		if !slices.Equal(current, o.Domains) {
+			c.FromDomains = current
+		}
+		changes = append(changes, c)
+	}
+
+	// owners maps the domains to the organizations holding them, as the
+	// changes are applied one after another.
+	owners := map[string]string{}
+	for _, e := range existing {
+		if matched[e.Id] {
+			for _, d := range existingDomains(&e) {
+				owners[d] = e.Id
+			}
+			continue
+		}
+		if prune {
+			plan.Changes = append(plan.Changes, organizationChange{Op: organizationDelete, ID: e.Id, Label: e.Label, Domains: existingDomains(&e)})
+			continue
+		}
+		plan.Unmanaged++
+		for _, d := range existingDomains(&e) {
+			owners[d] = e.Id
+			for _, c := range changes {
+				if slices.Contains(c.Domains, d) {
+					return nil, errors.Errorf("domain %s of organization %q is used by organization %q (%s), which is not in the file, add it to the file or use --prune to delete it", d, c.Label, e.Label, e.Id)
+				}
+			}
+		}
+	}
+
+	// A domain moving to another organization must be removed from the old
+	// one first.
+	for len(changes) > 0 {
+		i := slices.IndexFunc(changes, func(c organizationChange) bool {
+			for _, d := range c.Domains {
+				if owner, ok := owners[d]; ok && owner != c.ID {
+					return false
+				}
+			}
+			return true
+		})
+		if i < 0 {
+			labels := make([]string, len(changes))
+			for k, c := range changes {
+				labels[k] = fmt.Sprintf("%q", c.Label)
+			}
+			return nil, errors.Errorf("organizations %s swap domains, which can not be applied at once, move the domains in two steps", strings.Join(labels, ", "))
+		}
+
+		c := changes[i]
+		for _, d := range c.FromDomains {
+			delete(owners, d)
+		}
+		for _, d := range c.Domains {
+			owners[d] = ownerKey(c)
+		}
+		plan.Changes = append(plan.Changes, c)
+		changes = slices.Delete(changes, i, i+1)
+	}
+	return plan, nil
+}
+
+// ownerKey identifies the organization of a change, also if it is yet to be
+// created.
+func ownerKey(c organizationChange) string {
+	if c.ID != "" {
+		return c.ID
+	}
+	return "new:" + c.Label
+}
+
+func existingDomains(e *cloud.Organization) []string {
+	domains := make([]string, 0, len(e.Domains))
+	for _, d := range e.Domains {
+		domains = append(domains, normalizeDomain(d))
+	}
+	slices.Sort(domains)
+	return domains
+}
+
+func (p *organizationsPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p *organizationsPlan) String() string {
+	var b strings.Builder
+	if p.Empty() {
+		_, _ = fmt.Fprintf(&b, "No changes. The organizations of project %s match the file.\n", p.ProjectID)
+	} else {
+		_, _ = fmt.Fprintf(&b, "Organizations of project %s will be changed as follows:\n\n", p.ProjectID)
+		counts := map[string]int{}
+		for _, c := range p.Changes {
+			counts[c.Op]++
+			switch c.Op {
+			case organizationCreate:
+				_, _ = fmt.Fprintf(&b, "  + %s: domains [%s]\n", c.Label, strings.Join(c.Domains, ", "))
+			case organizationDelete:
+				_, _ = fmt.Fprintf(&b, "  - %s (%s)\n", c.Label, c.ID)
+			case organizationUpdate:
+				var diffs []string
+				if c.FromLabel != "" {
+					diffs = append(diffs, fmt.Sprintf("label %q -> %q", c.FromLabel, c.Label))
+				}
+				if c.FromDomains != nil {
+					diffs = append(diffs, fmt.Sprintf("domains [%s] -> [%s]", strings.Join(c.FromDomains, ", "), strings.Join(c.Domains, ", ")))
+				}
+				_, _ = fmt.Fprintf(&b, "  ~ %s (%s): %s\n", c.Label, c.ID, strings.Join(diffs, ", "))
+			}
+		}
+		_, _ = fmt.Fprintf(&b, "\nPlan: %d to create, %d to update, %d to delete.\n", counts[organizationCreate], counts[organizationUpdate], counts[organizationDelete])
+	}
+	if p.Unmanaged > 0 {
+		_, _ = fmt.Fprintf(&b, "%d organization(s) missing from the file are kept, use --prune to delete them.\n", p.Unmanaged)
+	}
+	return b.String()
+}
+
+func (p *organizationsPlan) Interface() any {
+	return p
+}
+
+// applyOrganizations applies the changes in order, and stops at the first
+// failure.
+func applyOrganizations(ctx context.Context, h *client.CommandHelper, plan *organizationsPlan) error {
+	for _, c := range plan.Changes {
+		var err error
+		switch c.Op {
+		case organizationCreate:
+			_, err = h.CreateOrganization(ctx, plan.ProjectID, cloud.OrganizationBody{Label: new(c.Label), Domains: c.Domains})
+		case organizationUpdate:
+			_, err = h.UpdateOrganization(ctx, plan.ProjectID, c.ID, cloud.OrganizationBody{Label: new(c.Label), Domains: c.Domains})
+		case organizationDelete:
+			err = h.DeleteOrganization(ctx, plan.ProjectID, c.ID)
+		}
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(h.VerboseErrWriter, "Organization %q: %sd\n", c.Label, c.Op)
+	}
+	return nil
+}
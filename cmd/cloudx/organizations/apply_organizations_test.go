@@ -0,0 +1,155 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package organizations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloud "github.com/ory/client-go"
+)
+
+func TestValidateDesiredOrganizations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("case=normalizes domains", func(t *testing.T) {
+		desired := []desiredOrganization{{Label: " Acme ", Domains: []string{"Acme.io", " acme.com", "acme.com"}}}
+		require.NoError(t, validateDesiredOrganizations(desired))
+		assert.Equal(t, "Acme", desired[0].Label)
+		assert.Equal(t, []string{"acme.com", "acme.io"}, desired[0].Domains)
+	})
+
+	for _, tc := range []struct {
+		name    string
+		desired []desiredOrganization
+		err     string
+	}{
+		{
+			name:    "duplicate domain",
+			desired: []desiredOrganization{{Label: "Acme", Domains: []string{"acme.com"}}, {Label: "Globex", Domains: []string{"ACME.com"}}},
+			err:     `domain acme.com is used by organizations "Acme" and "Globex"`,
+		},
+		{
+			name:    "duplicate label",
+			desired: []desiredOrganization{{Label: "Acme"}, {Label: "Acme"}},
+			err:     `more than one organization labelled "Acme"`,
+		},
+		{
+			name:    "duplicate id",
+			desired: []desiredOrganization{{ID: "1", Label: "Acme"}, {ID: "1", Label: "Globex"}},
+			err:     `organizations "Acme" and "Globex" have the same ID 1`,
+		},
+		{
+			name:    "missing label",
+			desired: []desiredOrganization{{Label: " "}},
+			err:     "organization #1 does not have a label",
+		},
+		{
+			name:    "invalid domain",
+			desired: []desiredOrganization{{Label: "Acme", Domains: []string{"https://acme.com"}}},
+			err:     `invalid domain "https://acme.com"`,
+		},
+	} {
+		t.Run("case="+tc.name, func(t *testing.T) {
+			assert.ErrorContains(t, validateDesiredOrganizations(tc.desired), tc.err)
+		})
+	}
+}
+
+func TestPlanOrganizations(t *testing.T) {
+	t.Parallel()
+
+	existing := []cloud.Organization{
+		{Id: "acme", Label: "Acme", Domains: []string{"acme.com"}},
+		{Id: "globex", Label: "Globex", Domains: []string{"globex.com", "globex.io"}},
+		{Id: "initech", Label: "Initech", Domains: []string{"initech.com"}},
+	}
+
+	t.Run("case=creates, updates and keeps unmanaged organizations", func(t *testing.T) {
+		desired := []desiredOrganization{
+			{Label: "Acme", Domains: []string{"acme.com"}},
+			{ID: "globex", Label: "Globex Inc.", Domains: []string{"globex.com"}},
+			{Label: "Umbrella", Domains: []string{"umbrella.com"}},
+		}
+		plan, err := planOrganizations("p", desired, existing, false)
+		require.NoError(t, err)
+		assert.Equal(t, []organizationChange{
+			{Op: organizationUpdate, ID: "globex", Label: "Globex Inc.", Domains: []string{"globex.com"}, FromLabel: "Globex", FromDomains: []string{"globex.com", "globex.io"}},
+			{Op: organizationCreate, Label: "Umbrella", Domains: []string{"umbrella.com"}},
+		}, plan.Changes)
+		assert.Equal(t, 1, plan.Unchanged)
+		assert.Equal(t, 1, plan.Unmanaged)
+		assert.Contains(t, plan.String(), "Plan: 1 to create, 1 to update, 0 to delete.")
+		assert.Contains(t, plan.String(), `~ Globex Inc. (globex): label "Globex" -> "Globex Inc.", domains [globex.com, globex.io] -> [globex.com]`)
+	})
+
+	t.Run("case=prunes and releases domains first", func(t *testing.T) {
+		desired := []desiredOrganization{
+			{Label: "Umbrella", Domains: []string{"globex.io"}},
+			{Label: "Acme", Domains: []string{"acme.com", "initech.com"}},
+			{Label: "Globex", Domains: []string{"globex.com"}},
+		}
+		plan, err := planOrganizations("p", desired, existing, true)
+		require.NoError(t, err)
+
+		ops := make([]string, len(plan.Changes))
+		for i, c := range plan.Changes {
+			ops[i] = c.Op + " " + c.Label
+		}
+		assert.Equal(t, []string{"delete Initech", "update Acme", "update Globex", "create Umbrella"}, ops)
+		assert.Zero(t, plan.Unmanaged)
+	})
+
+	t.Run("case=no changes", func(t *testing.T) {
+		desired := []desiredOrganization{
+			{Label: "Acme", Domains: []string{"acme.com"}},
+			{Label: "Globex", Domains: []string{"globex.com", "globex.io"}},
+			{Label: "Initech", Domains: []string{"initech.com"}},
+		}
+		plan, err := planOrganizations("p", desired, existing, true)
+		require.NoError(t, err)
+		assert.True(t, plan.Empty())
+		assert.Equal(t, 3, plan.Unchanged)
+	})
+
+	for _, tc := range []struct {
+		name    string
+		desired []desiredOrganization
+		prune   bool
+		err     string
+	}{
+		{
+			name:    "domain of unmanaged organization",
+			desired: []desiredOrganization{{Label: "Acme", Domains: []string{"acme.com", "initech.com"}}},
+			err:     `domain initech.com of organization "Acme" is used by organization "Initech" (initech)`,
+		},
+		{
+			name:    "unknown id",
+			desired: []desiredOrganization{{ID: "umbrella", Label: "Umbrella"}},
+			prune:   true,
+			err:     "the project has no organization with that ID",
+		},
+		{
+			name: "swapped domains",
+			desired: []desiredOrganization{
+				{Label: "Acme", Domains: []string{"globex.com"}},
+				{Label: "Globex", Domains: []string{"acme.com"}},
+			},
+			prune: true,
+			err:   `organizations "Acme", "Globex" swap domains`,
+		},
+	} {
+		t.Run("case="+tc.name, func(t *testing.T) {
+			_, err := planOrganizations("p", tc.desired, existing, tc.prune)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+
+	t.Run("case=ambiguous label", func(t *testing.T) {
+		_, err := planOrganizations("p", []desiredOrganization{{Label: "Acme"}}, append(existing, cloud.Organization{Id: "acme2", Label: "Acme"}), false)
+		assert.ErrorContains(t, err, `the project has 2 organizations labelled "Acme"`)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/cli/cmd/cloudx/eventstreams"
 	"github.com/ory/cli/cmd/cloudx/relationtuples"
 	"github.com/ory/x/cmdx"
 )
@@ -16,7 +17,10 @@ func NewParseCmd() *cobra.Command {
 		Use:   "parse",
 		Short: "Parse Ory Network resources",
 	}
-	cmd.AddCommand(relationtuples.NewParseCmd())
+	cmd.AddCommand(
+		relationtuples.NewParseCmd(),
+		eventstreams.NewParseEventCmd(),
+	)
 
 	client.RegisterConfigFlag(cmd.PersistentFlags())
 	client.RegisterYesFlag(cmd.PersistentFlags())
@@ -0,0 +1,132 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudx
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/x/cmdx"
+)
+
+func NewUseProfileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile [name]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Set the authentication profile as the default. When no name is provided, prints the currently used profile.",
+		Example: `$ ory auth login --profile acme
+$ ory use profile acme
+
+PROFILE	ID					EMAIL		SELECTED PROJECT	SELECTED WORKSPACE
+acme	ecaaa3cb-0730-4ee8-a6df-9553cdfeef89	jane@acme.com	<none>			<none>`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			// Only persist when the user asked to change the default. Without an
+			// argument this command just reports the profile in use, which must
+			// not overwrite the stored default with e.g. an ORY_PROFILE value.
+			if len(args) == 1 {
+				if err := h.SelectProfile(args[0]); err != nil {
+					return cmdx.PrintOpenAPIError(cmd, err)
+				}
+			}
+
+			profiles, err := h.ListProfiles()
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			for _, p := range profiles {
+				if p.Profile() == h.ProfileName() {
+					cmdx.PrintRow(cmd, (*outputProfile)(p))
+					return nil
+				}
+			}
+			return cmdx.PrintOpenAPIError(cmd, client.ErrNotAuthenticated)
+		},
+	}
+
+	cmdx.RegisterFormatFlags(cmd.Flags())
+	return cmd
+}
+
+func NewListProfilesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profiles",
+		Args:  cobra.NoArgs,
+		Short: "List the authentication profiles stored in the configuration file",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			profiles, err := h.ListProfiles()
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+
+			cmdx.PrintTable(cmd, outputProfiles(profiles))
+			return nil
+		},
+	}
+
+	cmdx.RegisterFormatFlags(cmd.Flags())
+	return cmd
+}
+
+type (
+	outputProfile  client.Config
+	outputProfiles []*client.Config
+)
+
+var (
+	_ cmdx.TableRow = (*outputProfile)(nil)
+	_ cmdx.Table    = (outputProfiles)(nil)
+)
+
+func (*outputProfile) Header() []string {
+	return append([]string{"PROFILE"}, new(client.Config).Header()...)
+}
+
+func (p *outputProfile) Columns() []string {
+	c := (*client.Config)(p)
+	return append([]string{c.Profile()}, c.Columns()...)
+}
+
+func (p *outputProfile) Interface() interface{} {
+	return profileJSON{Profile: (*client.Config)(p).Profile(), Config: (*client.Config)(p)}
+}
+
+// profileJSON adds the profile name to the JSON representation of a config.
+type profileJSON struct {
+	Profile string `json:"profile"`
+	*client.Config
+}
+
+func (outputProfiles) Header() []string {
+	return new(outputProfile).Header()
+}
+
+func (o outputProfiles) Table() [][]string {
+	rows := make([][]string, len(o))
+	for i, p := range o {
+		rows[i] = (*outputProfile)(p).Columns()
+	}
+	return rows
+}
+
+func (o outputProfiles) Interface() interface{} {
+	res := make([]profileJSON, len(o))
+	for i, p := range o {
+		res[i] = profileJSON{Profile: p.Profile(), Config: p}
+	}
+	return res
+}
+
+func (o outputProfiles) Len() int {
+	return len(o)
+}
@@ -0,0 +1,129 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+const applyLong = `The configuration file has the same format as the output of
+` + "`ory get project --format yaml`" + `. Only the name, the CORS settings, and the
+identity, permission, OAuth2, and account experience configuration are compared.
+Sections missing from the file are left untouched. Within a section present in
+the file, keys missing from the file are removed from the project.`
+
+func NewProjectPlanCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Args:  cobra.NoArgs,
+		Short: "Show the changes that applying a project configuration would make",
+		Long: `Compares the configuration file(s) with the live configuration of the Ory Network
+project and prints the changes ` + "`ory apply`" + ` would make, without changing anything.
+
+` + applyLong,
+		Example: `$ ory plan -f project.yaml
+
+Project ecaaa3cb-0730-4ee8-a6df-9553cdfeef89 will be changed as follows:
+
+  + /services/identity/config/selfservice/methods/totp/enabled = true
+  ~ /services/identity/config/courier/smtp/from_name: "Acme" -> "Acme Inc."
+  - /cors_public/origins = ["https://old.example.com"]
+
+Plan: 1 to add, 1 to change, 1 to remove.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+			plan, err := planFromFlags(cmd, h)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			cmdx.PrintJSONAble(cmd, plan)
+			return nil
+		},
+	}
+
+	registerApplyFlags(cmd)
+	return cmd
+}
+
+func NewProjectApplyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Args:  cobra.NoArgs,
+		Short: "Apply a project configuration",
+		Long: `Compares the configuration file(s) with the live configuration of the Ory Network
+project, prints the changes, and after confirmation applies them as a minimal
+JSON Patch. Use ` + "`ory plan`" + ` to only print the changes.
+
+` + applyLong,
+		Example: `$ ory apply -f project.yaml
+
+$ ory apply --project my-project -f identity.yaml -f permissions.yaml --yes`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+			plan, err := planFromFlags(cmd, h)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+
+			_, _ = fmt.Fprint(h.VerboseErrWriter, plan.String())
+			if plan.Empty() {
+				return nil
+			}
+			if ok, err := h.Confirm("Do you want to apply these changes?"); err != nil {
+				return err
+			} else if !ok {
+				_, _ = fmt.Fprintln(h.VerboseErrWriter, "Apply cancelled.")
+				return cmdx.FailSilently(cmd)
+			}
+
+			p, err := h.ApplyProjectPlan(cmd.Context(), plan)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			outputFullProject(cmd, p)
+			return h.PrintUpdateProjectWarnings(p)
+		},
+	}
+
+	registerApplyFlags(cmd)
+	client.RegisterIfMatchFlags(cmd.Flags())
+	return cmd
+}
+
+func registerApplyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceP("file", "f", nil, "Configuration file(s) (file://config.json, https://example.org/config.yaml, ...) describing the desired project configuration")
+	client.RegisterProjectFlag(cmd.Flags())
+	client.RegisterWorkspaceFlag(cmd.Flags())
+	cmdx.RegisterFormatFlags(cmd.Flags())
+}
+
+func planFromFlags(cmd *cobra.Command, h *client.CommandHelper) (*client.ProjectPlan, error) {
+	files := flagx.MustGetStringSlice(cmd, "file")
+	if len(files) == 0 {
+		return nil, errors.New("at least one configuration file must be set using --file")
+	}
+	configs, err := client.ReadAndParseFiles(files)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := h.ProjectID()
+	if err != nil {
+		return nil, err
+	}
+	return h.PlanProject(cmd.Context(), id, configs)
+}
@@ -0,0 +1,166 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// A project bundle is a directory holding a snapshot of an Ory Network project.
+// Each resource is stored in its own file, and the manifest lists the files
+// with their checksums, so that a bundle can be verified before it is imported.
+const (
+	bundleVersion      = "v1"
+	bundleManifestFile = "manifest.json"
+
+	bundleKindProject       = "project"
+	bundleKindOPL           = "opl"
+	bundleKindOrganizations = "organizations"
+	bundleKindEventStreams  = "event-streams"
+	bundleKindOAuth2Clients = "oauth2-clients"
+	bundleKindIdentities    = "identities"
+	bundleKindRelationships = "relationships"
+)
+
+// bundleFileNames are the file names used for each kind of resource.
+var bundleFileNames = map[string]string{
+	bundleKindProject:       "project.json",
+	bundleKindOPL:           "namespace_config.ts",
+	bundleKindOrganizations: "organizations.json",
+	bundleKindEventStreams:  "event_streams.json",
+	bundleKindOAuth2Clients: "oauth2_clients.json",
+	bundleKindIdentities:    "identities.json",
+	bundleKindRelationships: "relationships.json",
+}
+
+type (
+	bundleManifest struct {
+		Version    string        `json:"version"`
+		CLIVersion string        `json:"cli_version"`
+		CreatedAt  time.Time     `json:"created_at"`
+		Project    bundleProject `json:"project"`
+		Files      []bundleFile  `json:"files"`
+	}
+	bundleProject struct {
+		ID          string `json:"id"`
+		Slug        string `json:"slug"`
+		Name        string `json:"name"`
+		Environment string `json:"environment"`
+		RevisionID  string `json:"revision_id,omitempty"`
+	}
+	bundleFile struct {
+		Path   string `json:"path"`
+		Kind   string `json:"kind"`
+		SHA256 string `json:"sha256"`
+		// Count is the number of resources in the file, if it holds a list.
+		Count *int `json:"count,omitempty"`
+	}
+)
+
+// writeJSON writes v as indented JSON to the bundle and records it in the
+// manifest. Files are only readable by the current user, as they may contain
+// personal data.
+func (m *bundleManifest) writeJSON(dir, kind string, v any, count *int) error {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("unable to encode %s: %w", kind, err)
+	}
+	return m.writeFile(dir, kind, b.Bytes(), count)
+}
+
+func (m *bundleManifest) writeFile(dir, kind string, content []byte, count *int) error {
+	name := bundleFileNames[kind]
+	if err := os.WriteFile(filepath.Join(dir, name), content, 0600); err != nil {
+		return fmt.Errorf("unable to write %s: %w", name, err)
+	}
+	sum := sha256.Sum256(content)
+	m.Files = append(m.Files, bundleFile{
+		Path:   name,
+		Kind:   kind,
+		SHA256: hex.EncodeToString(sum[:]),
+		Count:  count,
+	})
+	return nil
+}
+
+func (m *bundleManifest) write(dir string) error {
+	raw, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, bundleManifestFile), append(raw, '\n'), 0600); err != nil {
+		return fmt.Errorf("unable to write %s: %w", bundleManifestFile, err)
+	}
+	return nil
+}
+
+// readBundle reads the manifest of the bundle in dir and the files listed in
+// it, keyed by kind. It fails if any file does not match its checksum.
+func readBundle(dir string) (*bundleManifest, map[string][]byte, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, bundleManifestFile))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read the bundle manifest, is %q a directory created by `ory export project`? %w", dir, err)
+	}
+	var m bundleManifest
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, nil, fmt.Errorf("unable to decode %s: %w", bundleManifestFile, err)
+	}
+	if m.Version != bundleVersion {
+		return nil, nil, fmt.Errorf("unsupported bundle version %q, this version of the Ory CLI reads bundles of version %q", m.Version, bundleVersion)
+	}
+
+	files := make(map[string][]byte, len(m.Files))
+	for _, f := range m.Files {
+		// The manifest must not make the CLI read files outside the bundle.
+		if f.Path != filepath.Base(f.Path) || f.Path == bundleManifestFile {
+			return nil, nil, fmt.Errorf("invalid file %q in %s", f.Path, bundleManifestFile)
+		}
+		content, err := os.ReadFile(filepath.Join(dir, f.Path))
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to read %s: %w", f.Path, err)
+		}
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != f.SHA256 {
+			return nil, nil, fmt.Errorf("the checksum of %s does not match the manifest, the bundle was modified or is incomplete", f.Path)
+		}
+		files[f.Kind] = content
+	}
+	if _, ok := files[bundleKindProject]; !ok {
+		return nil, nil, fmt.Errorf("the bundle does not contain a project configuration")
+	}
+	return &m, files, nil
+}
+
+func (*bundleManifest) Header() []string {
+	return []string{"PATH", "KIND", "COUNT", "SHA256"}
+}
+
+func (m *bundleManifest) Table() [][]string {
+	rows := make([][]string, len(m.Files))
+	for i, f := range m.Files {
+		count := "-"
+		if f.Count != nil {
+			count = fmt.Sprintf("%d", *f.Count)
+		}
+		rows[i] = []string{f.Path, f.Kind, count, f.SHA256}
+	}
+	return rows
+}
+
+func (m *bundleManifest) Interface() interface{} {
+	return m
+}
+
+func (m *bundleManifest) Len() int {
+	return len(m.Files)
+}
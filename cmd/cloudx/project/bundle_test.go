@@ -0,0 +1,46 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleManifest(t *testing.T) {
+	dir := t.TempDir()
+	m := &bundleManifest{Version: bundleVersion, Project: bundleProject{ID: "ecaaa3cb-0730-4ee8-a6df-9553cdfeef89"}}
+
+	require.NoError(t, m.writeJSON(dir, bundleKindOrganizations, []map[string]string{{"label": "acme"}}, new(1)))
+	require.NoError(t, m.writeFile(dir, bundleKindOPL, []byte("class User implements Namespace {}\n"), nil))
+	require.NoError(t, m.write(dir))
+
+	for _, f := range m.Files {
+		raw, err := os.ReadFile(filepath.Join(dir, f.Path))
+		require.NoError(t, err)
+		sum := sha256.Sum256(raw)
+		assert.Equal(t, hex.EncodeToString(sum[:]), f.SHA256, f.Path)
+
+		info, err := os.Stat(filepath.Join(dir, f.Path))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "bundle files may contain personal data")
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, bundleManifestFile))
+	require.NoError(t, err)
+	var written bundleManifest
+	require.NoError(t, json.Unmarshal(raw, &written))
+	assert.Equal(t, m.Files, written.Files)
+	assert.Equal(t, [][]string{
+		{"organizations.json", bundleKindOrganizations, "1", m.Files[0].SHA256},
+		{"namespace_config.ts", bundleKindOPL, "-", m.Files[1].SHA256},
+	}, written.Table())
+}
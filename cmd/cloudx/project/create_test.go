@@ -5,8 +5,6 @@ package project_test
 
 import (
 	"context"
-	"encoding/json"
-	"os"
 	"strings"
 	"testing"
 
@@ -43,9 +41,7 @@ func TestCreateProject(t *testing.T) {
 		ctx, newConfig := testhelpers.WithDuplicatedConfigFile(ctx, t, defaultConfig)
 		conf := testhelpers.ReadConfig(t, newConfig)
 		conf.SelectedWorkspace = uuid.Nil
-		rawConfig, err := json.Marshal(conf)
-		require.NoError(t, err)
-		require.NoError(t, os.WriteFile(newConfig, rawConfig, 0600))
+		testhelpers.WriteConfig(t, newConfig, conf)
 
 		for _, tc := range []struct {
 			expectedErr string
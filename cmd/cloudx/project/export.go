@@ -0,0 +1,192 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/buildinfo"
+	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+const (
+	flagOutput               = "output"
+	flagIncludeIdentities    = "include-identities"
+	flagIncludeRelationships = "include-relationships"
+)
+
+func NewExportProjectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project [id]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Export an Ory Network project as a bundle",
+		Long: `Writes a snapshot of an Ory Network project to a directory. The bundle contains
+the project configuration, the Ory Permission Language file, organizations,
+event streams, and OAuth2 clients, each in a separate file. Identities and
+relationships are only exported when requested.
+
+A manifest.json lists all files with their SHA-256 checksums, along with the
+versions of the bundle format, the Ory CLI, and the project configuration.
+
+OAuth2 client secrets and identity credentials are never exported.`,
+		Example: `$ ory export project --output ./backup
+
+PATH			KIND		COUNT	SHA256
+project.json		project		-	2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae
+namespace_config.ts	opl		-	fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9
+organizations.json	organizations	2	baa5a0964d3320fbc0c6a922140453c8513ea24ab8fd0577034804a967248096
+...
+
+$ ory export project my-project --output ./backup --include-identities --include-relationships`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			opts := make([]client.CommandHelperOption, 0, 1)
+			if len(args) == 1 {
+				opts = append(opts, client.WithProjectOverride(args[0]))
+			}
+			h, err := client.NewCobraCommandHelper(cmd, opts...)
+			if err != nil {
+				return err
+			}
+
+			dir := flagx.MustGetString(cmd, flagOutput)
+			if dir == "" {
+				return errors.New("the output directory must be set using --output")
+			}
+			if _, err := os.Stat(filepath.Join(dir, bundleManifestFile)); err == nil {
+				if ok, err := h.Confirm(fmt.Sprintf("The directory %q already contains a bundle. Do you want to overwrite it?", dir)); err != nil {
+					return err
+				} else if !ok {
+					return cmdx.FailSilently(cmd)
+				}
+				// Remove the files of the previous export, so that none of them
+				// outlives the manifest listing it.
+				for _, name := range append(slices.Collect(maps.Values(bundleFileNames)), bundleManifestFile) {
+					if err := os.Remove(filepath.Join(dir, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+						return fmt.Errorf("unable to remove the previous bundle: %w", err)
+					}
+				}
+			}
+			if err := os.MkdirAll(dir, 0700); err != nil {
+				return fmt.Errorf("unable to create the output directory: %w", err)
+			}
+
+			pID, err := h.ProjectID()
+			if err != nil {
+				return err
+			}
+			project, err := h.GetProject(ctx, pID, nil)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+
+			m := &bundleManifest{
+				Version:    bundleVersion,
+				CLIVersion: buildinfo.Version,
+				CreatedAt:  time.Now().UTC().Round(time.Second),
+				Project: bundleProject{
+					ID:          project.Id,
+					Slug:        project.Slug,
+					Name:        project.Name,
+					Environment: project.Environment,
+					RevisionID:  project.RevisionId,
+				},
+			}
+
+			if err := m.writeJSON(dir, bundleKindProject, project, nil); err != nil {
+				return err
+			}
+
+			location, err := oplLocation(project.Services.GetPermission().Config)
+			switch {
+			case errors.Is(err, errNoOPLConfigured), errors.Is(err, errLegacyNamespaces):
+				// Nothing to fetch, legacy namespaces are part of the project
+				// configuration.
+			case err != nil:
+				return err
+			default:
+				opl, err := newOPLFetcher().FetchBytes(ctx, location)
+				if err != nil {
+					return fmt.Errorf("unable to read the Ory Permission Language file: %w", err)
+				}
+				if err := m.writeFile(dir, bundleKindOPL, opl, nil); err != nil {
+					return err
+				}
+			}
+
+			orgs, err := h.ListOrganizations(ctx, project.Id)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			if err := m.writeJSON(dir, bundleKindOrganizations, orgs.Organizations, new(len(orgs.Organizations))); err != nil {
+				return err
+			}
+
+			streams, err := h.ListEventStreams(ctx, project.Id)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			if err := m.writeJSON(dir, bundleKindEventStreams, streams.EventStreams, new(len(streams.EventStreams))); err != nil {
+				return err
+			}
+
+			clients, err := h.ListOAuth2Clients(ctx, project.Slug)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			if err := m.writeJSON(dir, bundleKindOAuth2Clients, clients, new(len(clients))); err != nil {
+				return err
+			}
+
+			if flagx.MustGetBool(cmd, flagIncludeIdentities) {
+				identities, err := h.ListIdentities(ctx, project.Slug)
+				if err != nil {
+					return cmdx.PrintOpenAPIError(cmd, err)
+				}
+				if err := m.writeJSON(dir, bundleKindIdentities, identities, new(len(identities))); err != nil {
+					return err
+				}
+			}
+
+			if flagx.MustGetBool(cmd, flagIncludeRelationships) {
+				relationships, err := h.ListRelationships(ctx, project.Slug)
+				if err != nil {
+					return cmdx.PrintOpenAPIError(cmd, err)
+				}
+				if err := m.writeJSON(dir, bundleKindRelationships, relationships, new(len(relationships))); err != nil {
+					return err
+				}
+			}
+
+			// The manifest is written last, so that an interrupted export does
+			// not leave a bundle behind that looks complete.
+			if err := m.write(dir); err != nil {
+				return err
+			}
+
+			cmdx.PrintTable(cmd, m)
+			_, _ = fmt.Fprintf(h.VerboseErrWriter, "Project %s exported to %s successfully!\n", project.Slug, dir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP(flagOutput, "o", "", "The directory to write the bundle to.")
+	cmd.Flags().Bool(flagIncludeIdentities, false, "Also export all identities. They contain personal data, store the bundle accordingly.")
+	cmd.Flags().Bool(flagIncludeRelationships, false, "Also export all relationships (permission tuples).")
+	client.RegisterProjectFlag(cmd.Flags())
+	client.RegisterWorkspaceFlag(cmd.Flags())
+	cmdx.RegisterFormatFlags(cmd.Flags())
+	return cmd
+}
@@ -0,0 +1,162 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+const flagRevision = "revision"
+
+const historyLong = `Every time the CLI changes the configuration of a project, for example using
+` + "`ory update`, `ory patch`, or `ory apply`" + `, the previous configuration is
+recorded in a local history next to the CLI's config file. The history only
+contains changes made from this machine.`
+
+func NewProjectHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project",
+		Args:  cobra.NoArgs,
+		Short: "List the recorded configurations of a project",
+		Long: `Lists the configurations of the Ory Network project recorded before the CLI
+changed them.
+
+` + historyLong + `
+
+Use ` + "`ory diff project --revision N`" + ` to show how a revision differs from the
+current configuration, and ` + "`ory rollback project --revision N`" + ` to restore it.`,
+		Example: `$ ory history project --project my-project
+
+REVISION	CREATED AT		USER		PROJECT REVISION ID
+1		2026-10-01T09:12:44Z	Jane Doe	5f2d1d53-0f0e-4a4c-8f5b-2a6a6f5e6b0e
+2		2026-10-02T15:03:10Z	Jane Doe	0c8f2a7e-5b7d-4a4f-9f3c-1b9e8e3f2d11`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+			id, err := h.ProjectID()
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			history, err := h.ProjectHistory(id)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			cmdx.PrintTable(cmd, history)
+			return nil
+		},
+	}
+
+	client.RegisterProjectFlag(cmd.Flags())
+	client.RegisterWorkspaceFlag(cmd.Flags())
+	cmdx.RegisterFormatFlags(cmd.Flags())
+	return cmd
+}
+
+func NewDiffProjectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project",
+		Args:  cobra.NoArgs,
+		Short: "Show how a recorded configuration differs from the current one",
+		Long: `Prints the changes rolling the Ory Network project back to a recorded
+configuration would make, without changing anything.
+
+` + historyLong,
+		Example: `$ ory diff project --project my-project --revision 2
+
+Project ecaaa3cb-0730-4ee8-a6df-9553cdfeef89 will be changed as follows:
+
+  ~ /services/identity/config/session/lifespan: "48h" -> "24h"
+
+Plan: 0 to add, 1 to change, 0 to remove.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+			plan, err := planRollbackFromFlags(cmd, h)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			cmdx.PrintJSONAble(cmd, plan)
+			return nil
+		},
+	}
+
+	registerRevisionFlags(cmd)
+	return cmd
+}
+
+func NewRollbackProjectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project",
+		Args:  cobra.NoArgs,
+		Short: "Restore a recorded configuration of a project",
+		Long: `Restores the configuration of the Ory Network project to a recorded one. The
+changes are printed, and applied after confirmation. The rollback itself is
+recorded in the history as well, so it can be undone.
+
+` + historyLong,
+		Example: `$ ory rollback project --project my-project --revision 2`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+			plan, err := planRollbackFromFlags(cmd, h)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+
+			_, _ = fmt.Fprint(h.VerboseErrWriter, plan.String())
+			if plan.Empty() {
+				return nil
+			}
+			if ok, err := h.Confirm(fmt.Sprintf("Do you want to roll back to revision %d?", flagx.MustGetInt(cmd, flagRevision))); err != nil {
+				return err
+			} else if !ok {
+				_, _ = fmt.Fprintln(h.VerboseErrWriter, "Rollback cancelled.")
+				return cmdx.FailSilently(cmd)
+			}
+
+			p, err := h.ApplyProjectPlan(cmd.Context(), plan)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			outputFullProject(cmd, p)
+			return h.PrintUpdateProjectWarnings(p)
+		},
+	}
+
+	registerRevisionFlags(cmd)
+	client.RegisterIfMatchFlags(cmd.Flags())
+	return cmd
+}
+
+func registerRevisionFlags(cmd *cobra.Command) {
+	cmd.Flags().Int(flagRevision, 0, "The revision of the recorded configuration, as listed by `ory history project`.")
+	client.RegisterProjectFlag(cmd.Flags())
+	client.RegisterWorkspaceFlag(cmd.Flags())
+	cmdx.RegisterFormatFlags(cmd.Flags())
+}
+
+func planRollbackFromFlags(cmd *cobra.Command, h *client.CommandHelper) (*client.ProjectPlan, error) {
+	revision := flagx.MustGetInt(cmd, flagRevision)
+	if revision <= 0 {
+		return nil, errors.New("the revision must be set using --revision")
+	}
+	id, err := h.ProjectID()
+	if err != nil {
+		return nil, err
+	}
+	return h.PlanRollback(cmd.Context(), id, revision)
+}
@@ -0,0 +1,708 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	cloud "github.com/ory/client-go"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+const (
+	flagCreate      = "create"
+	flagName        = "name"
+	flagEnvironment = "environment"
+	flagDryRun      = "dry-run"
+
+	actionCreate    = "create"
+	actionUpdate    = "update"
+	actionUnchanged = "unchanged"
+)
+
+type (
+	// bundleAction is one step of importing a bundle into a project.
+	bundleAction struct {
+		Kind    string `json:"kind"`
+		Name    string `json:"name"`
+		Action  string `json:"action"`
+		Details string `json:"details,omitempty"`
+
+		// do performs the action, it is nil for unchanged resources.
+		do func(ctx context.Context) error
+	}
+	bundleImport struct {
+		Project string         `json:"project"`
+		DryRun  bool           `json:"dry_run"`
+		Actions []bundleAction `json:"actions"`
+	}
+
+	// bundleImporter imports the resources of a bundle into a project.
+	bundleImporter struct {
+		h        *client.CommandHelper
+		files    map[string][]byte
+		project  *cloud.Project
+		manifest *bundleManifest
+		// orgIDs maps the organization IDs of the bundle to the ones of the
+		// target project, so that identities keep their organization.
+		orgIDs map[string]string
+		// excludes are the JSON pointers of the configuration that keep the
+		// target project's values.
+		excludes []string
+	}
+)
+
+func NewImportProjectBundleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project-bundle <dir>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Import a project bundle into an Ory Network project",
+		Long: `Replays a bundle created by ` + "`ory export project`" + ` onto an Ory Network project,
+either an existing one or, with ` + "`--create`" + `, a new one.
+
+The bundle's checksums are verified first. The import is idempotent, so it can
+be run again after a failure or to sync changes:
+
+- The project configuration is applied like ` + "`ory apply`" + `, keeping the project's name.
+  Values specific to an environment keep the target project's values, the same
+  as with ` + "`ory promote project`" + `. Add more using --exclude, or use
+  --no-default-excludes to only exclude the pointers you set.
+- The Ory Permission Language file is uploaded like ` + "`ory update opl`" + `.
+- Organizations are matched by label.
+- Event streams are matched by type and destination.
+- OAuth2 clients are matched by ID, then by name. New clients get new secrets.
+- Identities are matched by ID, external ID, or address, and are never
+  overwritten. New identities get new IDs, so identities with neither an
+  external ID nor an address are skipped unless they exist with the same ID.
+  Credentials are not part of the bundle, so imported identities must recover
+  their accounts or sign in with a social sign-in provider.
+- Relationships are matched exactly.
+
+Use ` + "`--dry-run`" + ` to only print what would be done.`,
+		Example: `$ ory import project-bundle ./backup --project my-staging-project --dry-run
+
+$ ory import project-bundle ./backup --create --name "Production" --environment prod`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			manifest, files, err := readBundle(args[0])
+			if err != nil {
+				return err
+			}
+
+			dryRun := flagx.MustGetBool(cmd, flagDryRun)
+			create := flagx.MustGetBool(cmd, flagCreate)
+			name := flagx.MustGetString(cmd, flagName)
+			environment := flagx.MustGetString(cmd, flagEnvironment)
+			if create && cmd.Flags().Changed(client.FlagProject) {
+				return errors.New("--create and --project are mutually exclusive")
+			}
+			if !create && (name != "" || environment != "") {
+				return errors.New("--name and --environment can only be used together with --create")
+			}
+
+			excludes := flagx.MustGetStringSlice(cmd, flagExclude)
+			if !flagx.MustGetBool(cmd, flagNoDefaultExcludes) {
+				excludes = slices.Concat(client.DefaultPromotionExcludes, excludes)
+			}
+
+			imp := &bundleImporter{h: h, files: files, manifest: manifest, orgIDs: map[string]string{}, excludes: excludes}
+			result := &bundleImport{DryRun: dryRun}
+			if create {
+				if name == "" || environment == "" {
+					return errors.New("--create requires --name and --environment")
+				}
+				result.Project = name
+				// A new project is empty, so everything in the bundle is
+				// created, and the plan does not need to ask the API.
+				imp.project = &cloud.Project{Name: name, Environment: environment}
+				result.Actions = append(result.Actions, bundleAction{
+					Kind:    bundleKindProject,
+					Name:    name,
+					Action:  actionCreate,
+					Details: "environment " + environment,
+				})
+			} else {
+				pID, err := h.ProjectID()
+				if err != nil {
+					return err
+				}
+				imp.project, err = h.GetProject(ctx, pID, nil)
+				if err != nil {
+					return cmdx.PrintOpenAPIError(cmd, err)
+				}
+				result.Project = imp.project.Slug
+			}
+
+			actions, err := imp.plan(ctx, create)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			result.Actions = append(result.Actions, actions...)
+
+			if dryRun {
+				cmdx.PrintTable(cmd, result)
+				return nil
+			}
+
+			_, _ = fmt.Fprintf(h.VerboseErrWriter, "Importing the bundle of project %s (%s) into %s:\n\n", manifest.Project.Slug, manifest.Project.Environment, result.Project)
+			for _, a := range result.Actions {
+				_, _ = fmt.Fprintf(h.VerboseErrWriter, "  %-9s %s %s\n", a.Action, a.Kind, a.Name)
+			}
+			_, _ = fmt.Fprintln(h.VerboseErrWriter)
+			if ok, err := h.Confirm("Do you want to import the bundle?"); err != nil {
+				return err
+			} else if !ok {
+				_, _ = fmt.Fprintln(h.VerboseErrWriter, "Import cancelled.")
+				return cmdx.FailSilently(cmd)
+			}
+
+			if create {
+				workspace := h.WorkspaceID()
+				imp.project, err = h.CreateProject(ctx, name, environment, workspace, false)
+				if err != nil {
+					return cmdx.PrintOpenAPIError(cmd, err)
+				}
+				result.Project = imp.project.Slug
+				// The plan was made without a project, make it again now that
+				// there is one to compare against, after the action creating
+				// the project.
+				actions, err := imp.plan(ctx, false)
+				if err != nil {
+					return cmdx.PrintOpenAPIError(cmd, err)
+				}
+				result.Actions = append(result.Actions[:1], actions...)
+			}
+
+			for _, a := range result.Actions {
+				if a.do == nil {
+					continue
+				}
+				if err := a.do(ctx); err != nil {
+					_, _ = fmt.Fprintf(h.VerboseErrWriter, "The import stopped at %s %q. It is safe to run it again once the error is resolved.\n", a.Kind, a.Name)
+					return cmdx.PrintOpenAPIError(cmd, err)
+				}
+			}
+
+			cmdx.PrintTable(cmd, result)
+			_, _ = fmt.Fprintln(h.VerboseErrWriter, "Project bundle imported successfully!")
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool(flagCreate, false, "Create a new project to import the bundle into.")
+	cmd.Flags().String(flagName, "", "The name of the project to create.")
+	cmd.Flags().String(flagEnvironment, "", "The environment of the project to create: prod, stage, or dev.")
+	cmd.Flags().Bool(flagDryRun, false, "Only print what would be imported.")
+	cmd.Flags().StringSlice(flagExclude, nil, "JSON pointer(s) of the configuration to keep as they are in the target project, in addition to the defaults.")
+	cmd.Flags().Bool(flagNoDefaultExcludes, false, "Do not exclude the default environment-specific JSON pointers.")
+	client.RegisterProjectFlag(cmd.Flags())
+	client.RegisterIfMatchFlags(cmd.Flags())
+	client.RegisterWorkspaceFlag(cmd.Flags())
+	return cmd
+}
+
+// plan computes the actions importing the bundle. If empty is set, the target
+// project does not exist yet and all resources are created.
+func (imp *bundleImporter) plan(ctx context.Context, empty bool) ([]bundleAction, error) {
+	var actions []bundleAction
+
+	if !empty {
+		a, err := imp.planProjectConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, a...)
+	} else {
+		actions = append(actions, bundleAction{Kind: bundleKindProject, Name: "configuration", Action: actionUpdate})
+		if _, ok := imp.files[bundleKindOPL]; ok {
+			actions = append(actions, bundleAction{Kind: bundleKindOPL, Name: bundleFileNames[bundleKindOPL], Action: actionUpdate})
+		}
+	}
+
+	var orgs []cloud.Organization
+	if err := imp.decode(bundleKindOrganizations, &orgs); err != nil {
+		return nil, err
+	}
+	if len(orgs) > 0 {
+		var existing []cloud.Organization
+		if !empty {
+			res, err := imp.h.ListOrganizations(ctx, imp.project.Id)
+			if err != nil {
+				return nil, err
+			}
+			existing = res.Organizations
+		}
+		actions = append(actions, imp.planOrganizations(orgs, existing)...)
+	}
+
+	var streams []cloud.EventStream
+	if err := imp.decode(bundleKindEventStreams, &streams); err != nil {
+		return nil, err
+	}
+	if len(streams) > 0 {
+		var existing []cloud.EventStream
+		if !empty {
+			res, err := imp.h.ListEventStreams(ctx, imp.project.Id)
+			if err != nil {
+				return nil, err
+			}
+			existing = res.EventStreams
+		}
+		actions = append(actions, imp.planEventStreams(streams, existing)...)
+	}
+
+	var clients []cloud.OAuth2Client
+	if err := imp.decode(bundleKindOAuth2Clients, &clients); err != nil {
+		return nil, err
+	}
+	if len(clients) > 0 {
+		var existing []cloud.OAuth2Client
+		if !empty {
+			var err error
+			if existing, err = imp.h.ListOAuth2Clients(ctx, imp.project.Slug); err != nil {
+				return nil, err
+			}
+		}
+		actions = append(actions, imp.planOAuth2Clients(clients, existing)...)
+	}
+
+	var identities []cloud.Identity
+	if err := imp.decode(bundleKindIdentities, &identities); err != nil {
+		return nil, err
+	}
+	if len(identities) > 0 {
+		var existing []cloud.Identity
+		if !empty {
+			var err error
+			if existing, err = imp.h.ListIdentities(ctx, imp.project.Slug); err != nil {
+				return nil, err
+			}
+		}
+		actions = append(actions, imp.planIdentities(identities, existing))
+	}
+
+	var relationships []cloud.Relationship
+	if err := imp.decode(bundleKindRelationships, &relationships); err != nil {
+		return nil, err
+	}
+	if len(relationships) > 0 {
+		var existing []cloud.Relationship
+		if !empty {
+			var err error
+			if existing, err = imp.h.ListRelationships(ctx, imp.project.Slug); err != nil {
+				return nil, err
+			}
+		}
+		actions = append(actions, imp.planRelationships(relationships, existing))
+	}
+
+	return actions, nil
+}
+
+func (imp *bundleImporter) decode(kind string, v any) error {
+	raw, ok := imp.files[kind]
+	if !ok {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("unable to decode %s: %w", bundleFileNames[kind], err)
+	}
+	return nil
+}
+
+// planProjectConfig plans the configuration like `ory plan`. The bundle's OPL
+// file replaces the permission config's pointer to the source project's file.
+func (imp *bundleImporter) planProjectConfig(ctx context.Context) ([]bundleAction, error) {
+	var desired map[string]any
+	if err := imp.decode(bundleKindProject, &desired); err != nil {
+		return nil, err
+	}
+	// The target keeps its own name and environment-specific values.
+	delete(desired, "name")
+	desired, err := client.KeepExcludedConfig(desired, imp.project, imp.excludes)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []bundleAction
+	if opl, ok := imp.files[bundleKindOPL]; ok {
+		location := "base64://" + base64.StdEncoding.EncodeToString(opl)
+		action := bundleAction{Kind: bundleKindOPL, Name: bundleFileNames[bundleKindOPL], Action: actionUpdate}
+		// Ory Network stores the file and replaces the location, so compare
+		// the contents to keep the import idempotent.
+		if current, err := oplLocation(imp.project.Services.GetPermission().Config); err == nil {
+			if live, err := newOPLFetcher().FetchBytes(ctx, current); err == nil && bytes.Equal(live, opl) {
+				location = current
+				action.Action = actionUnchanged
+			}
+		}
+		if err := setJSONPath(desired, []string{"services", "permission", "config", "namespaces"}, map[string]any{"location": location}); err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+
+	raw, err := json.Marshal(desired)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := imp.h.PlanProject(ctx, imp.project.Id, []json.RawMessage{raw})
+	if err != nil {
+		return nil, err
+	}
+
+	config := bundleAction{Kind: bundleKindProject, Name: "configuration", Action: actionUnchanged}
+	if !plan.Empty() {
+		config.Action = actionUpdate
+		config.Details = fmt.Sprintf("%d changes", len(plan.Changes))
+		config.do = func(ctx context.Context) error {
+			res, err := imp.h.ApplyProjectPlan(ctx, plan)
+			if err != nil {
+				return err
+			}
+			return imp.h.PrintUpdateProjectWarnings(res)
+		}
+	}
+	// The OPL is part of the configuration, so it is applied along with it.
+	return append([]bundleAction{config}, actions...), nil
+}
+
+func setJSONPath(doc map[string]any, path []string, value any) error {
+	for _, key := range path[:len(path)-1] {
+		next, ok := doc[key].(map[string]any)
+		if !ok {
+			if _, exists := doc[key]; exists {
+				return fmt.Errorf("unable to set %s in the project configuration", strings.Join(path, "."))
+			}
+			next = map[string]any{}
+			doc[key] = next
+		}
+		doc = next
+	}
+	doc[path[len(path)-1]] = value
+	return nil
+}
+
+func (imp *bundleImporter) planOrganizations(orgs, existing []cloud.Organization) []bundleAction {
+	byLabel := make(map[string]cloud.Organization, len(existing))
+	for _, e := range existing {
+		byLabel[e.Label] = e
+	}
+
+	actions := make([]bundleAction, 0, len(orgs))
+	for _, o := range orgs {
+		body := cloud.OrganizationBody{
+			Label:           new(o.Label),
+			Domains:         o.Domains,
+			DefaultRegion:   o.DefaultRegion,
+			SessionLifespan: o.SessionLifespan,
+		}
+		a := bundleAction{Kind: bundleKindOrganizations, Name: o.Label}
+
+		e, ok := byLabel[o.Label]
+		switch {
+		case !ok:
+			a.Action = actionCreate
+			a.do = func(ctx context.Context) error {
+				created, err := imp.h.CreateOrganization(ctx, imp.project.Id, body)
+				if err != nil {
+					return err
+				}
+				imp.orgIDs[o.Id] = created.Id
+				return nil
+			}
+		case slices.Equal(slices.Sorted(slices.Values(e.Domains)), slices.Sorted(slices.Values(o.Domains))) &&
+			reflect.DeepEqual(e.DefaultRegion, o.DefaultRegion) &&
+			reflect.DeepEqual(e.SessionLifespan, o.SessionLifespan):
+			a.Action = actionUnchanged
+			imp.orgIDs[o.Id] = e.Id
+		default:
+			a.Action = actionUpdate
+			imp.orgIDs[o.Id] = e.Id
+			a.do = func(ctx context.Context) error {
+				_, err := imp.h.UpdateOrganization(ctx, imp.project.Id, e.Id, body)
+				return err
+			}
+		}
+		actions = append(actions, a)
+	}
+	return actions
+}
+
+// eventStreamDestination identifies an event stream independent of its ID.
+func eventStreamDestination(s cloud.EventStream) string {
+	if s.GetType() == "sns" {
+		return s.GetTopicArn()
+	}
+	return s.GetHttpsEndpoint()
+}
+
+func (imp *bundleImporter) planEventStreams(streams, existing []cloud.EventStream) []bundleAction {
+	byDestination := make(map[string]cloud.EventStream, len(existing))
+	for _, e := range existing {
+		byDestination[e.GetType()+" "+eventStreamDestination(e)] = e
+	}
+
+	actions := make([]bundleAction, 0, len(streams))
+	for _, s := range streams {
+		a := bundleAction{Kind: bundleKindEventStreams, Name: eventStreamDestination(s), Details: s.GetType()}
+		e, ok := byDestination[s.GetType()+" "+eventStreamDestination(s)]
+		switch {
+		case !ok:
+			a.Action = actionCreate
+			a.do = func(ctx context.Context) error {
+				_, err := imp.h.CreateEventStream(ctx, imp.project.Id, cloud.CreateEventStreamBody{
+					Type:          s.GetType(),
+					HttpsEndpoint: s.HttpsEndpoint.Get(),
+					TopicArn:      s.TopicArn,
+					RoleArn:       s.RoleArn,
+					Status:        s.Status,
+				})
+				return err
+			}
+		case e.GetStatus() != s.GetStatus() || e.GetRoleArn() != s.GetRoleArn():
+			a.Action = actionUpdate
+			a.do = func(ctx context.Context) error {
+				_, err := imp.h.UpdateEventStream(ctx, imp.project.Id, e.GetId(), cloud.SetEventStreamBody{
+					Status:  s.Status,
+					RoleArn: s.RoleArn,
+				})
+				return err
+			}
+		default:
+			a.Action = actionUnchanged
+		}
+		actions = append(actions, a)
+	}
+	return actions
+}
+
+// oauth2ClientVolatileKeys are set by the server and not part of a client's
+// configuration.
+var oauth2ClientVolatileKeys = []string{
+	"client_id", "client_secret", "client_secret_expires_at",
+	"created_at", "updated_at",
+	"registration_access_token", "registration_client_uri",
+}
+
+func comparableOAuth2Client(c cloud.OAuth2Client) map[string]any {
+	raw, _ := json.Marshal(c)
+	var m map[string]any
+	_ = json.Unmarshal(raw, &m)
+	for _, k := range oauth2ClientVolatileKeys {
+		delete(m, k)
+	}
+	return m
+}
+
+func (imp *bundleImporter) planOAuth2Clients(clients, existing []cloud.OAuth2Client) []bundleAction {
+	byID := make(map[string]cloud.OAuth2Client, len(existing))
+	byName := make(map[string]cloud.OAuth2Client, len(existing))
+	for _, e := range existing {
+		byID[e.GetClientId()] = e
+		if e.GetClientName() != "" {
+			byName[e.GetClientName()] = e
+		}
+	}
+
+	actions := make([]bundleAction, 0, len(clients))
+	for _, c := range clients {
+		a := bundleAction{Kind: bundleKindOAuth2Clients, Name: cmp.Or(c.GetClientName(), c.GetClientId())}
+
+		e, ok := byID[c.GetClientId()]
+		if !ok && c.GetClientName() != "" {
+			e, ok = byName[c.GetClientName()]
+		}
+
+		body := c
+		body.ClientId, body.ClientSecret = nil, nil
+		switch {
+		case !ok:
+			a.Action = actionCreate
+			a.do = func(ctx context.Context) error {
+				created, err := imp.h.CreateOAuth2Client(ctx, imp.project.Slug, body)
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintf(imp.h.VerboseErrWriter, "Created OAuth2 client %q with ID %s", a.Name, created.GetClientId())
+				if created.ClientSecret != nil {
+					_, _ = fmt.Fprintf(imp.h.VerboseErrWriter, " and secret %s", created.GetClientSecret())
+				}
+				_, _ = fmt.Fprintln(imp.h.VerboseErrWriter, ".")
+				return nil
+			}
+		case reflect.DeepEqual(comparableOAuth2Client(c), comparableOAuth2Client(e)):
+			a.Action = actionUnchanged
+		default:
+			a.Action = actionUpdate
+			a.do = func(ctx context.Context) error {
+				_, err := imp.h.SetOAuth2Client(ctx, imp.project.Slug, e.GetClientId(), body)
+				return err
+			}
+		}
+		actions = append(actions, a)
+	}
+	return actions
+}
+
+// identityKeys returns the keys an identity is matched by: its ID, external
+// ID, and addresses.
+func identityKeys(i cloud.Identity) []string {
+	keys := []string{"id:" + i.Id}
+	if i.GetExternalId() != "" {
+		keys = append(keys, "external_id:"+i.GetExternalId())
+	}
+	for _, a := range i.VerifiableAddresses {
+		keys = append(keys, "address:"+strings.ToLower(a.Value))
+	}
+	for _, a := range i.RecoveryAddresses {
+		keys = append(keys, "address:"+strings.ToLower(a.Value))
+	}
+	return keys
+}
+
+func (imp *bundleImporter) planIdentities(identities, existing []cloud.Identity) bundleAction {
+	known := make(map[string]struct{})
+	for _, e := range existing {
+		for _, k := range identityKeys(e) {
+			known[k] = struct{}{}
+		}
+	}
+
+	// The API assigns new IDs, so identities without an external ID or an
+	// address would be created again on every import.
+	var create []cloud.Identity
+	unmatchable := 0
+	for _, i := range identities {
+		keys := identityKeys(i)
+		switch {
+		case slices.ContainsFunc(keys, func(k string) bool { _, ok := known[k]; return ok }):
+			// The identity exists already.
+		case len(keys) == 1:
+			unmatchable++
+		default:
+			create = append(create, i)
+		}
+	}
+
+	a := bundleAction{
+		Kind:    bundleKindIdentities,
+		Name:    fmt.Sprintf("%d identities", len(identities)),
+		Action:  actionUnchanged,
+		Details: fmt.Sprintf("%d to create, %d existing", len(create), len(identities)-len(create)-unmatchable),
+	}
+	if unmatchable > 0 {
+		a.Details += fmt.Sprintf(", %d skipped without external ID or address", unmatchable)
+	}
+	if len(create) > 0 {
+		a.Action = actionCreate
+		a.do = func(ctx context.Context) error {
+			for _, i := range create {
+				body := cloud.CreateIdentityBody{
+					SchemaId:            i.SchemaId,
+					ExternalId:          i.ExternalId,
+					MetadataAdmin:       i.MetadataAdmin,
+					MetadataPublic:      i.MetadataPublic,
+					State:               i.State,
+					RecoveryAddresses:   i.RecoveryAddresses,
+					VerifiableAddresses: i.VerifiableAddresses,
+				}
+				if traits, ok := i.Traits.(map[string]any); ok {
+					body.Traits = traits
+				}
+				if org := i.OrganizationId.Get(); org != nil {
+					body.OrganizationId = *cloud.NewNullableString(new(cmp.Or(imp.orgIDs[*org], *org)))
+				}
+				if _, err := imp.h.CreateIdentity(ctx, imp.project.Slug, body); err != nil {
+					return fmt.Errorf("identity %s: %w", i.Id, err)
+				}
+			}
+			return nil
+		}
+	}
+	return a
+}
+
+func relationshipKey(r cloud.Relationship) string {
+	key := r.Namespace + ":" + r.Object + "#" + r.Relation + "@"
+	if r.SubjectSet != nil {
+		return key + r.SubjectSet.Namespace + ":" + r.SubjectSet.Object + "#" + r.SubjectSet.Relation
+	}
+	return key + r.GetSubjectId()
+}
+
+func (imp *bundleImporter) planRelationships(relationships, existing []cloud.Relationship) bundleAction {
+	known := make(map[string]struct{}, len(existing))
+	for _, e := range existing {
+		known[relationshipKey(e)] = struct{}{}
+	}
+
+	// The map also removes duplicates from the bundle.
+	create := make(map[string]cloud.Relationship)
+	found := 0
+	for _, r := range relationships {
+		if _, ok := known[relationshipKey(r)]; ok {
+			found++
+		} else {
+			create[relationshipKey(r)] = r
+		}
+	}
+
+	a := bundleAction{
+		Kind:    bundleKindRelationships,
+		Name:    fmt.Sprintf("%d relationships", len(relationships)),
+		Action:  actionUnchanged,
+		Details: fmt.Sprintf("%d to create, %d existing", len(create), found),
+	}
+	if len(create) > 0 {
+		a.Action = actionCreate
+		a.do = func(ctx context.Context) error {
+			rs := make([]cloud.Relationship, 0, len(create))
+			for _, k := range slices.Sorted(maps.Keys(create)) {
+				rs = append(rs, create[k])
+			}
+			return imp.h.CreateRelationships(ctx, imp.project.Slug, rs)
+		}
+	}
+	return a
+}
+
+func (*bundleImport) Header() []string {
+	return []string{"KIND", "NAME", "ACTION", "DETAILS"}
+}
+
+func (r *bundleImport) Table() [][]string {
+	rows := make([][]string, len(r.Actions))
+	for i, a := range r.Actions {
+		rows[i] = []string{a.Kind, a.Name, a.Action, cmp.Or(a.Details, "-")}
+	}
+	return rows
+}
+
+func (r *bundleImport) Interface() interface{} {
+	return r
+}
+
+func (r *bundleImport) Len() int {
+	return len(r.Actions)
+}
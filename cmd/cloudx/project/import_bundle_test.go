@@ -0,0 +1,144 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloud "github.com/ory/client-go"
+)
+
+func TestReadBundle(t *testing.T) {
+	write := func(t *testing.T) string {
+		dir := t.TempDir()
+		m := &bundleManifest{Version: bundleVersion}
+		require.NoError(t, m.writeJSON(dir, bundleKindProject, map[string]any{"name": "Example"}, nil))
+		require.NoError(t, m.writeJSON(dir, bundleKindOrganizations, []map[string]string{{"label": "acme"}}, new(1)))
+		require.NoError(t, m.write(dir))
+		return dir
+	}
+
+	t.Run("case=valid", func(t *testing.T) {
+		m, files, err := readBundle(write(t))
+		require.NoError(t, err)
+		assert.Len(t, m.Files, 2)
+		assert.JSONEq(t, `[{"label":"acme"}]`, string(files[bundleKindOrganizations]))
+	})
+
+	t.Run("case=modified file", func(t *testing.T) {
+		dir := write(t)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, bundleFileNames[bundleKindOrganizations]), []byte(`[]`), 0600))
+		_, _, err := readBundle(dir)
+		assert.ErrorContains(t, err, "checksum of organizations.json does not match")
+	})
+
+	t.Run("case=path outside of bundle", func(t *testing.T) {
+		dir := t.TempDir()
+		m := &bundleManifest{Version: bundleVersion, Files: []bundleFile{{Path: "../project.json", Kind: bundleKindProject}}}
+		require.NoError(t, m.write(dir))
+		_, _, err := readBundle(dir)
+		assert.ErrorContains(t, err, "invalid file")
+	})
+
+	t.Run("case=unknown version", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, (&bundleManifest{Version: "v0"}).write(dir))
+		_, _, err := readBundle(dir)
+		assert.ErrorContains(t, err, "unsupported bundle version")
+	})
+}
+
+func TestPlanBundleImport(t *testing.T) {
+	imp := &bundleImporter{orgIDs: map[string]string{}}
+
+	t.Run("kind=organizations", func(t *testing.T) {
+		actions := imp.planOrganizations(
+			[]cloud.Organization{
+				{Id: "src-1", Label: "acme", Domains: []string{"acme.com", "acme.org"}},
+				{Id: "src-2", Label: "globex", Domains: []string{"globex.com"}},
+				{Id: "src-3", Label: "initech"},
+			},
+			[]cloud.Organization{
+				{Id: "dst-1", Label: "acme", Domains: []string{"acme.org", "acme.com"}},
+				{Id: "dst-2", Label: "globex", Domains: []string{"globex.net"}},
+			},
+		)
+		require.Len(t, actions, 3)
+		assert.Equal(t, actionUnchanged, actions[0].Action)
+		assert.Nil(t, actions[0].do)
+		assert.Equal(t, actionUpdate, actions[1].Action)
+		assert.Equal(t, actionCreate, actions[2].Action)
+		assert.Equal(t, map[string]string{"src-1": "dst-1", "src-2": "dst-2"}, imp.orgIDs)
+	})
+
+	t.Run("kind=event streams", func(t *testing.T) {
+		actions := imp.planEventStreams(
+			[]cloud.EventStream{
+				{Type: new("https"), HttpsEndpoint: *cloud.NewNullableString(new("https://example.com/a")), Status: new("active")},
+				{Type: new("https"), HttpsEndpoint: *cloud.NewNullableString(new("https://example.com/b")), Status: new("active")},
+				{Type: new("sns"), TopicArn: new("arn:aws:sns:topic"), RoleArn: new("arn:aws:iam::role/a")},
+			},
+			[]cloud.EventStream{
+				{Id: new("1"), Type: new("https"), HttpsEndpoint: *cloud.NewNullableString(new("https://example.com/a")), Status: new("active")},
+				{Id: new("2"), Type: new("https"), HttpsEndpoint: *cloud.NewNullableString(new("https://example.com/b")), Status: new("paused")},
+			},
+		)
+		require.Len(t, actions, 3)
+		assert.Equal(t, []string{actionUnchanged, actionUpdate, actionCreate}, []string{actions[0].Action, actions[1].Action, actions[2].Action})
+	})
+
+	t.Run("kind=oauth2 clients", func(t *testing.T) {
+		actions := imp.planOAuth2Clients(
+			[]cloud.OAuth2Client{
+				{ClientId: new("a"), ClientName: new("By ID"), RedirectUris: []string{"https://example.com/cb"}},
+				{ClientId: new("b"), ClientName: new("By name"), Scope: new("openid")},
+				{ClientId: new("c"), ClientName: new("New")},
+			},
+			[]cloud.OAuth2Client{
+				{ClientId: new("a"), ClientName: new("By ID"), RedirectUris: []string{"https://example.com/cb"}, ClientSecret: new("secret"), CreatedAt: new(time.Now())},
+				{ClientId: new("x"), ClientName: new("By name"), Scope: new("openid offline")},
+			},
+		)
+		require.Len(t, actions, 3)
+		assert.Equal(t, []string{actionUnchanged, actionUpdate, actionCreate}, []string{actions[0].Action, actions[1].Action, actions[2].Action})
+	})
+
+	t.Run("kind=identities", func(t *testing.T) {
+		a := imp.planIdentities(
+			[]cloud.Identity{
+				{Id: "1"},
+				{Id: "2", ExternalId: new("ext")},
+				{Id: "3", VerifiableAddresses: []cloud.VerifiableIdentityAddress{{Value: "Foo@Example.com"}}},
+				{Id: "4"},
+				{Id: "7", ExternalId: new("new-ext")},
+			},
+			[]cloud.Identity{
+				{Id: "1"},
+				{Id: "5", ExternalId: new("ext")},
+				{Id: "6", RecoveryAddresses: []cloud.RecoveryIdentityAddress{{Value: "foo@example.com"}}},
+			},
+		)
+		assert.Equal(t, actionCreate, a.Action)
+		assert.Equal(t, "1 to create, 3 existing, 1 skipped without external ID or address", a.Details, "identities that can not be matched again are skipped")
+
+		a = imp.planIdentities([]cloud.Identity{{Id: "1"}}, []cloud.Identity{{Id: "1"}})
+		assert.Equal(t, actionUnchanged, a.Action)
+		assert.Nil(t, a.do)
+	})
+
+	t.Run("kind=relationships", func(t *testing.T) {
+		doc := cloud.Relationship{Namespace: "Document", Object: "readme", Relation: "viewers", SubjectId: new("alice")}
+		set := cloud.Relationship{Namespace: "Document", Object: "readme", Relation: "viewers", SubjectSet: &cloud.SubjectSet{Namespace: "Group", Object: "eng", Relation: "members"}}
+
+		a := imp.planRelationships([]cloud.Relationship{doc, set, set}, []cloud.Relationship{doc})
+		assert.Equal(t, actionCreate, a.Action)
+		assert.Equal(t, "1 to create, 1 existing", a.Details, "duplicates in the bundle are only created once")
+	})
+}
@@ -51,6 +51,8 @@ The format of the patch is a JSON-Patch document. For more details please check:
 	cmd.Flags().StringArray("add", nil, "Add a specific key to the configuration")
 	cmd.Flags().StringArray("remove", nil, "Remove a specific key from the configuration")
 	client.RegisterYesFlag(cmd.Flags())
+	registerSkipValidationFlag(cmd)
+	client.RegisterIfMatchFlags(cmd.Flags())
 	client.RegisterProjectFlag(cmd.Flags())
 	client.RegisterWorkspaceFlag(cmd.Flags())
 	cmdx.RegisterFormatFlags(cmd.Flags())
@@ -92,6 +94,11 @@ func runPatch(patchPrefixer func([]string) []string, filePrefixer func([]json.Ra
 		if err != nil {
 			return cmdx.PrintOpenAPIError(cmd, err)
 		}
+		if !flagx.MustGetBool(cmd, flagSkipValidation) {
+			if err := h.ValidateProjectPatch(ctx, id, configs, add, replace, remove); err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+		}
 		p, err := h.PatchProject(ctx, id, configs, add, replace, remove)
 		if err != nil {
 			return cmdx.PrintOpenAPIError(cmd, err)
@@ -60,6 +60,8 @@ The format of the patch is a JSON-Patch document. For more details please check:
 	cmd.Flags().StringArray("add", nil, "Add a specific key to the configuration")
 	cmd.Flags().StringArray("remove", nil, "Remove a specific key from the configuration")
 	client.RegisterYesFlag(cmd.Flags())
+	registerSkipValidationFlag(cmd)
+	client.RegisterIfMatchFlags(cmd.Flags())
 	client.RegisterProjectFlag(cmd.Flags())
 	client.RegisterWorkspaceFlag(cmd.Flags())
 	cmdx.RegisterFormatFlags(cmd.Flags())
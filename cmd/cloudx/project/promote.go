@@ -0,0 +1,100 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+const (
+	flagFrom              = "from"
+	flagTo                = "to"
+	flagExclude           = "exclude"
+	flagNoDefaultExcludes = "no-default-excludes"
+)
+
+func NewPromoteProjectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project",
+		Args:  cobra.NoArgs,
+		Short: "Copy the configuration of one project to another",
+		Long: `Copies the identity, permission, OAuth2, and account experience configuration
+of a project to another one, for example from a staging to a production project.
+The name, CORS settings, and organizations of the target project are not changed.
+
+Values specific to an environment, such as base URLs, cookie domains, secrets,
+and SMTP credentials, are kept as they are in the target project. By default
+these JSON pointers are excluded:
+
+  ` + strings.Join(client.DefaultPromotionExcludes, "\n  ") + `
+
+Add more using --exclude. A "*" segment matches every key of an object or every
+element of an array. Use --no-default-excludes to only exclude the pointers you
+set.
+
+The changes are printed, and applied after confirmation.`,
+		Example: `$ ory promote project --from my-staging-project --to my-production-project
+
+$ ory promote project --from my-staging-project --to my-production-project \
+  --exclude /services/identity/config/selfservice/flows/registration/enabled`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			from, to := flagx.MustGetString(cmd, flagFrom), flagx.MustGetString(cmd, flagTo)
+			if from == "" || to == "" {
+				return errors.New("both --from and --to must be set")
+			}
+			excludes := flagx.MustGetStringSlice(cmd, flagExclude)
+			if !flagx.MustGetBool(cmd, flagNoDefaultExcludes) {
+				excludes = slices.Concat(client.DefaultPromotionExcludes, excludes)
+			}
+
+			promotion, err := h.PlanPromotion(cmd.Context(), from, to, excludes)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+
+			_, _ = fmt.Fprintf(h.VerboseErrWriter, "Promoting the configuration of project %s (%s) to project %s (%s).\n\n",
+				promotion.From.Slug, promotion.From.Environment, promotion.To.Slug, promotion.To.Environment)
+			_, _ = fmt.Fprint(h.VerboseErrWriter, promotion.Plan.String())
+			if promotion.Plan.Empty() {
+				return nil
+			}
+			if ok, err := h.Confirm(fmt.Sprintf("Do you want to apply these changes to project %s?", promotion.To.Slug)); err != nil {
+				return err
+			} else if !ok {
+				_, _ = fmt.Fprintln(h.VerboseErrWriter, "Promotion cancelled.")
+				return cmdx.FailSilently(cmd)
+			}
+
+			p, err := h.ApplyPromotion(cmd.Context(), promotion)
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+			outputFullProject(cmd, p)
+			return h.PrintUpdateProjectWarnings(p)
+		},
+	}
+
+	cmd.Flags().String(flagFrom, "", "The ID or slug of the project to copy the configuration from.")
+	cmd.Flags().String(flagTo, "", "The ID or slug of the project to copy the configuration to.")
+	cmd.Flags().StringSlice(flagExclude, nil, "JSON pointer(s) to keep as they are in the target project, in addition to the defaults.")
+	cmd.Flags().Bool(flagNoDefaultExcludes, false, "Do not exclude the default environment-specific JSON pointers.")
+	client.RegisterWorkspaceFlag(cmd.Flags())
+	client.RegisterIfMatchFlags(cmd.Flags())
+	cmdx.RegisterFormatFlags(cmd.Flags())
+	return cmd
+}
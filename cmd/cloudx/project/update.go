@@ -6,6 +6,7 @@ package project
 import (
 	"encoding/json"
 	"io"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -80,19 +81,23 @@ As an example an input could look like:
 	  }
 	}
 `,
-		RunE: runUpdate(prefixFileNop, outputFullProject),
+		RunE: runUpdate("", outputFullProject),
 	}
 
 	cmd.Flags().StringP("name", "n", "", "The new name of the project.")
 	cmd.Flags().StringSliceP("file", "f", nil, "Configuration file(s) (file://config.json, https://example.org/config.yaml, ...) to update the project")
 	client.RegisterYesFlag(cmd.Flags())
+	registerSkipValidationFlag(cmd)
+	client.RegisterIfMatchFlags(cmd.Flags())
 	client.RegisterProjectFlag(cmd.Flags())
 	client.RegisterWorkspaceFlag(cmd.Flags())
 	cmdx.RegisterFormatFlags(cmd.Flags())
 	return cmd
 }
 
-func runUpdate(filePrefixer func([]json.RawMessage) ([]json.RawMessage, error), outputter func(*cobra.Command, *cloud.SuccessfulProjectUpdate)) func(*cobra.Command, []string) error {
+// runUpdate updates the project with the configuration files, which are placed
+// at the JSON pointer configPointer in the project configuration.
+func runUpdate(configPointer string, outputter func(*cobra.Command, *cloud.SuccessfulProjectUpdate)) func(*cobra.Command, []string) error {
 	return func(cmd *cobra.Command, args []string) (err error) {
 		opts := make([]client.CommandHelperOption, 0, 1)
 		if len(args) == 1 {
@@ -103,24 +108,36 @@ func runUpdate(filePrefixer func([]json.RawMessage) ([]json.RawMessage, error),
 			return err
 		}
 
-		var configs []json.RawMessage
+		var sources []client.ProjectConfigSource
 		files := flagx.MustGetStringSlice(cmd, "file")
 		if len(files) == 0 {
 			content, err := io.ReadAll(cmd.InOrStdin())
 			if err != nil {
 				return errors.New("error reading from STDIN: use --file flag to read from a file instead: " + err.Error())
 			}
-			configs = []json.RawMessage{content}
+			sources = []client.ProjectConfigSource{{Name: "STDIN", Prefix: configPointer, Content: content, Config: content}}
 		} else {
-			configs, err = client.ReadAndParseFiles(files)
+			sources, err = client.ReadProjectConfigSources(files, configPointer)
 			if err != nil {
 				return err
 			}
 		}
 
-		configs, err = filePrefixer(configs)
-		if err != nil {
-			return err
+		configs := make([]json.RawMessage, len(sources))
+		for k, s := range sources {
+			configs[k] = s.Config
+		}
+		if configPointer != "" {
+			configs, err = prefixFileConfig(strings.ReplaceAll(strings.TrimPrefix(configPointer, "/"), "/", "."), configs)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !flagx.MustGetBool(cmd, flagSkipValidation) {
+			if err := client.ValidateProjectConfig(configs, sources); err != nil {
+				return err
+			}
 		}
 
 		name := ""
@@ -64,11 +64,13 @@ This command expects the contents of the ` + "`/services/identity/config`" + ` k
 	  }
 	}
 `,
-		RunE: runUpdate(prefixFileIdentityConfig, outputIdentityConfig),
+		RunE: runUpdate(identityConfigPointer, outputIdentityConfig),
 	}
 
 	cmd.Flags().StringSliceP("file", "f", nil, "Configuration file(s) (file://config.json, https://example.org/config.yaml, ...) to update the identity config")
 	client.RegisterYesFlag(cmd.Flags())
+	registerSkipValidationFlag(cmd)
+	client.RegisterIfMatchFlags(cmd.Flags())
 	client.RegisterProjectFlag(cmd.Flags())
 	client.RegisterWorkspaceFlag(cmd.Flags())
 	cmdx.RegisterNoiseFlags(cmd.Flags())
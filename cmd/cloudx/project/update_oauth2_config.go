@@ -67,11 +67,13 @@ This command expects the contents of the ` + "`/services/oauth2/config`" + ` key
       ]
     }
 `,
-		RunE: runUpdate(prefixFileOAuth2Config, outputOAuth2Config),
+		RunE: runUpdate(oauth2ConfigPointer, outputOAuth2Config),
 	}
 
 	cmd.Flags().StringSliceP("file", "f", nil, "Configuration file(s) (file://config.json, https://example.org/config.yaml, ...) to update the oAuth2 config")
 	client.RegisterYesFlag(cmd.Flags())
+	registerSkipValidationFlag(cmd)
+	client.RegisterIfMatchFlags(cmd.Flags())
 	client.RegisterProjectFlag(cmd.Flags())
 	client.RegisterWorkspaceFlag(cmd.Flags())
 	cmdx.RegisterNoiseFlags(cmd.Flags())
@@ -66,11 +66,13 @@ This command expects the contents of the ` + "`/services/permission/config`" + `
       ]
     }
 `,
-		RunE: runUpdate(prefixFilePermissionConfig, outputPermissionConfig),
+		RunE: runUpdate(permissionConfigPointer, outputPermissionConfig),
 	}
 
 	cmd.Flags().StringSliceP("file", "f", nil, "Configuration file(s) (file://config.json, https://example.org/config.yaml, ...) to update the permission config")
 	client.RegisterYesFlag(cmd.Flags())
+	registerSkipValidationFlag(cmd)
+	client.RegisterIfMatchFlags(cmd.Flags())
 	client.RegisterProjectFlag(cmd.Flags())
 	client.RegisterWorkspaceFlag(cmd.Flags())
 	cmdx.RegisterNoiseFlags(cmd.Flags())
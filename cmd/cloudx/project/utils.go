@@ -13,6 +13,19 @@ import (
 	"github.com/ory/x/cmdx"
 )
 
+// The JSON pointers of the services' configuration within a project.
+const (
+	identityConfigPointer   = "/services/identity/config"
+	permissionConfigPointer = "/services/permission/config"
+	oauth2ConfigPointer     = "/services/oauth2/config"
+)
+
+const flagSkipValidation = "skip-validation"
+
+func registerSkipValidationFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(flagSkipValidation, false, "Do not validate the configuration against the JSON schemas of the Ory services before sending it.")
+}
+
 func prefixConfig(prefix string, s []string) []string {
 	for k := range s {
 		s[k] = prefix + s[k]
@@ -21,15 +34,15 @@ func prefixConfig(prefix string, s []string) []string {
 }
 
 func prefixIdentityConfig(s []string) []string {
-	return prefixConfig("/services/identity/config", s)
+	return prefixConfig(identityConfigPointer, s)
 }
 
 func prefixPermissionConfig(s []string) []string {
-	return prefixConfig("/services/permission/config", s)
+	return prefixConfig(permissionConfigPointer, s)
 }
 
 func prefixOAuth2Config(s []string) []string {
-	return prefixConfig("/services/oauth2/config", s)
+	return prefixConfig(oauth2ConfigPointer, s)
 }
 
 func prefixFileConfig(prefix string, configs []json.RawMessage) ([]json.RawMessage, error) {
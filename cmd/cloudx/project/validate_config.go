@@ -0,0 +1,64 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/flagx"
+)
+
+func NewValidateProjectConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project-config",
+		Args:  cobra.NoArgs,
+		Short: "Validate a project configuration locally",
+		Long: `Validates project configuration file(s) against the JSON schemas of Ory Identities,
+Ory OAuth2 & OpenID Connect, and Ory Permissions, without contacting Ory Network.
+The files are merged the same way as by ` + "`ory update project`" + `, and every
+problem is printed with its JSON pointer and the file and line it comes from.
+
+The same validation runs before ` + "`ory update`" + ` and ` + "`ory patch`" + ` send a
+configuration, unless ` + "`--skip-validation`" + ` is set.`,
+		Example: `$ ory validate project-config -f project.yaml -f identity-overrides.yaml
+
+POINTER								MESSAGE					SOURCE
+/services/identity/config/selfservice/methods/totp/enabled	expected boolean, but got string	identity-overrides.yaml:7`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			files := flagx.MustGetStringSlice(cmd, "file")
+			if len(files) == 0 {
+				return errors.New("at least one configuration file must be set using --file")
+			}
+			sources, err := client.ReadProjectConfigSources(files, "")
+			if err != nil {
+				return err
+			}
+			configs := make([]json.RawMessage, len(sources))
+			for k, s := range sources {
+				configs[k] = s.Config
+			}
+
+			err = client.ValidateProjectConfig(configs, sources)
+			var verr *client.ProjectConfigValidationError
+			if errors.As(err, &verr) {
+				cmdx.PrintTable(cmd, verr)
+				return cmdx.FailSilently(cmd)
+			} else if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "The project configuration is valid.")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceP("file", "f", nil, "Configuration file(s) (file://config.json, https://example.org/config.yaml, ...) to validate")
+	return cmd
+}
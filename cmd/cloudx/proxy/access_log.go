@@ -0,0 +1,302 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+)
+
+const (
+	LogFormatFlag   = "log-format"
+	InspectFlag     = "inspect"
+	InspectSizeFlag = "inspect-size"
+)
+
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+
+	targetOry      = "ory"
+	targetUpstream = "upstream"
+
+	redacted = "[redacted]"
+)
+
+func registerAccessLogFlags(conf *config, flags *pflag.FlagSet) {
+	flags.StringVar(&conf.logFormat, LogFormatFlag, logFormatText, "The format of the access log, either "+logFormatText+" or "+logFormatJSON+".")
+	flags.BoolVar(&conf.inspect, InspectFlag, false, "Show the last requests and responses, with secrets redacted, at "+path.Join("/.ory", inspectPath)+".")
+	flags.IntVar(&conf.inspectSize, InspectSizeFlag, 50, "The number of requests and responses to keep for --"+InspectFlag+".")
+}
+
+const inspectPath = "/proxy/inspect"
+
+type (
+	// exchange is a request and the response to it, as seen by the proxy.
+	exchange struct {
+		Time    time.Time `json:"time"`
+		Method  string    `json:"method"`
+		URL     string    `json:"url"`
+		Status  int       `json:"status"`
+		Latency float64   `json:"latency_ms"`
+		// Target is either "ory" or "upstream", and Upstream the host the
+		// request was forwarded to.
+		Target   string `json:"target,omitempty"`
+		Upstream string `json:"upstream,omitempty"`
+		Location string `json:"location,omitempty"`
+		// SetCookies are the names of the cookies set by the response.
+		SetCookies []string `json:"set_cookies,omitempty"`
+		// Session is whether the request had an active Ory Session. It is unset
+		// if the session was not looked up.
+		Session *bool `json:"session,omitempty"`
+		// JWT is whether the Ory Session was passed to the upstream as a JWT.
+		JWT bool `json:"jwt"`
+
+		RequestHeader  http.Header `json:"request_header,omitempty"`
+		ResponseHeader http.Header `json:"response_header,omitempty"`
+	}
+	exchangeContextKey struct{}
+
+	// exchangeLog keeps the last exchanges for the inspector.
+	exchangeLog struct {
+		mu        sync.Mutex
+		size      int
+		exchanges []*exchange
+	}
+)
+
+// exchangeFromContext returns the exchange the request is part of, so that the
+// middlewares and the proxy can record what they did.
+func exchangeFromContext(ctx context.Context) *exchange {
+	ex, _ := ctx.Value(exchangeContextKey{}).(*exchange)
+	return ex
+}
+
+func (l *exchangeLog) add(ex *exchange) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.exchanges = append(l.exchanges, ex)
+	if len(l.exchanges) > l.size {
+		l.exchanges = slices.Delete(l.exchanges, 0, len(l.exchanges)-l.size)
+	}
+}
+
+// last returns the exchanges, newest first.
+func (l *exchangeLog) last() []*exchange {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	last := slices.Clone(l.exchanges)
+	slices.Reverse(last)
+	return last
+}
+
+// newExchangeLog returns the log for the inspector, or nil if it is disabled.
+func newExchangeLog(conf *config) *exchangeLog {
+	if !conf.inspect {
+		return nil
+	}
+	return &exchangeLog{size: max(conf.inspectSize, 1)}
+}
+
+// accessLogMiddleware logs every request in the configured format, and keeps
+// the last ones in the log for the inspector if it is set.
+func accessLogMiddleware(conf *config, log *exchangeLog, stdErr io.Writer) (func(http.ResponseWriter, *http.Request, http.HandlerFunc), error) {
+	if conf.logFormat != logFormatText && conf.logFormat != logFormatJSON {
+		return nil, errors.Errorf("the log format must be either %s or %s", logFormatText, logFormatJSON)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request, n http.HandlerFunc) {
+		if log != nil && r.URL.Path == conf.proxyPath(inspectPath) {
+			serveInspector(w, r, log.last())
+			return
+		}
+
+		start := time.Now()
+		ex := &exchange{
+			Time:          start.UTC(),
+			Method:        r.Method,
+			URL:           redactURL(r.URL),
+			RequestHeader: redactHeader(r.Header),
+		}
+		r = r.WithContext(context.WithValue(r.Context(), exchangeContextKey{}, ex))
+
+		if conf.logFormat == logFormatText {
+			_, _ = fmt.Fprintf(stdErr, "%s [%s]\n", r.Method, r.URL)
+		}
+		statusCatcher := &responseStatusCatcher{ResponseWriter: w}
+		n(statusCatcher, r)
+
+		ex.Status = responseStatus(statusCatcher.status)
+		ex.Latency = float64(time.Since(start).Microseconds()) / 1000
+		ex.Location = redactLocation(statusCatcher.Header().Get("Location"))
+		for _, c := range (&http.Response{Header: statusCatcher.Header()}).Cookies() {
+			ex.SetCookies = append(ex.SetCookies, c.Name)
+		}
+		ex.ResponseHeader = redactHeader(statusCatcher.Header())
+
+		switch conf.logFormat {
+		case logFormatText:
+			_, _ = fmt.Fprintf(stdErr, "=> %d %s [%s] took %s\n", statusCatcher.status, r.Method, r.URL, time.Since(start))
+		case logFormatJSON:
+			entry := *ex
+			entry.RequestHeader, entry.ResponseHeader = nil, nil
+			raw, _ := json.Marshal(entry)
+			_, _ = fmt.Fprintf(stdErr, "%s\n", raw)
+		}
+		if log != nil {
+			log.add(ex)
+		}
+	}, nil
+}
+
+// responseStatus returns the status of a response, which is 200 unless set
+// explicitly.
+func responseStatus(status int) int {
+	if status == 0 {
+		return http.StatusOK
+	}
+	return status
+}
+
+// redactedQueryParameters carry credentials, for example the logout token.
+var redactedQueryParameters = []string{"token", "code", "session_token"}
+
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	changed := false
+	for _, name := range redactedQueryParameters {
+		if q.Has(name) {
+			q.Set(name, redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return u.String()
+	}
+	redactedURL := *u
+	redactedURL.RawQuery = q.Encode()
+	return redactedURL.String()
+}
+
+// redactLocation redacts the query parameters of a redirect, which carry for
+// example OAuth2 authorization codes and recovery tokens.
+func redactLocation(location string) string {
+	if location == "" {
+		return ""
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return redacted
+	}
+	return redactURL(u)
+}
+
+// redactHeader returns a copy of the header without credentials. Cookie names
+// and attributes are kept, as they help with debugging.
+func redactHeader(header http.Header) http.Header {
+	redactedHeader := header.Clone()
+	for name, values := range redactedHeader {
+		switch http.CanonicalHeaderKey(name) {
+		case "Cookie":
+			for k, v := range values {
+				cookies := strings.Split(v, ";")
+				for i, c := range cookies {
+					if n, _, ok := strings.Cut(c, "="); ok {
+						cookies[i] = n + "=" + redacted
+					}
+				}
+				values[k] = strings.Join(cookies, ";")
+			}
+		case "Set-Cookie":
+			for k, v := range values {
+				cookie, attributes, _ := strings.Cut(v, ";")
+				if n, _, ok := strings.Cut(cookie, "="); ok {
+					values[k] = n + "=" + redacted
+					if attributes != "" {
+						values[k] += ";" + attributes
+					}
+				}
+			}
+		case "Authorization":
+			for k, v := range values {
+				if scheme, _, ok := strings.Cut(v, " "); ok {
+					values[k] = scheme + " " + redacted
+				} else {
+					values[k] = redacted
+				}
+			}
+		case "Location":
+			for k, v := range values {
+				values[k] = redactLocation(v)
+			}
+		case "X-Session-Token", "Ory-Base-Url-Rewrite-Token", "Proxy-Authorization":
+			for k := range values {
+				values[k] = redacted
+			}
+		}
+	}
+	return redactedHeader
+}
+
+var inspectorTemplate = template.Must(template.New("inspector").Funcs(template.FuncMap{
+	"deref": func(b *bool) bool { return *b },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Ory Proxy Inspector</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em; }
+details { border-bottom: 1px solid #ddd; padding: .5em 0; }
+summary { cursor: pointer; font-family: monospace; }
+table { border-collapse: collapse; margin: .5em 0 .5em 1.5em; font-family: monospace; font-size: .9em; }
+td { padding: .1em .5em; vertical-align: top; }
+.error { color: #b00; }
+</style>
+</head>
+<body>
+<h1>Ory Proxy Inspector</h1>
+<p>The last {{len .}} requests, newest first. Credentials are redacted. Reload the page to update it.</p>
+{{range .}}
+<details>
+<summary{{if ge .Status 400}} class="error"{{end}}>{{.Time.Format "15:04:05"}} {{.Status}} {{.Method}} {{.URL}} &rarr; {{.Target}} {{.Upstream}} ({{printf "%.1f" .Latency}}ms){{if .Location}} &rarr; {{.Location}}{{end}}</summary>
+<table>
+<tr><td>Session</td><td>{{if .Session}}{{if deref .Session}}active{{else}}none{{end}}{{else}}not looked up{{end}}</td></tr>
+<tr><td>JWT</td><td>{{if .JWT}}attached{{else}}not attached{{end}}</td></tr>
+{{if .SetCookies}}<tr><td>Cookies set</td><td>{{range .SetCookies}}{{.}} {{end}}</td></tr>{{end}}
+</table>
+<strong>Request headers</strong>
+<table>{{range $name, $values := .RequestHeader}}{{range $values}}<tr><td>{{$name}}</td><td>{{.}}</td></tr>{{end}}{{end}}</table>
+<strong>Response headers</strong>
+<table>{{range $name, $values := .ResponseHeader}}{{range $values}}<tr><td>{{$name}}</td><td>{{.}}</td></tr>{{end}}{{end}}</table>
+</details>
+{{end}}
+</body>
+</html>
+`))
+
+// serveInspector shows the exchanges as HTML, or as JSON if requested.
+func serveInspector(w http.ResponseWriter, r *http.Request, exchanges []*exchange) {
+	w.Header().Set("Cache-Control", "no-store")
+	if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(exchanges)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = inspectorTemplate.Execute(w, exchanges)
+}
@@ -0,0 +1,127 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessLog(t *testing.T) {
+	var stdErr bytes.Buffer
+	conf := &config{pathPrefix: "/.ory", logFormat: logFormatJSON, inspect: true, inspectSize: 2}
+	mw, err := accessLogMiddleware(conf, newExchangeLog(conf), &stdErr)
+	require.NoError(t, err)
+
+	next := func(w http.ResponseWriter, r *http.Request) {
+		ex := exchangeFromContext(r.Context())
+		require.NotNil(t, ex)
+		ex.Target, ex.Upstream = targetOry, "example.projects.oryapis.com"
+		ex.Session, ex.JWT = new(true), true
+
+		http.SetCookie(w, &http.Cookie{Name: "ory_session_example", Value: "secret-session", Path: "/", HttpOnly: true})
+		w.Header().Set("Location", "http://localhost:4000/welcome")
+		w.WriteHeader(http.StatusSeeOther)
+	}
+	serve := func(target string, header http.Header) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", target, nil)
+		r.Header = header
+		w := httptest.NewRecorder()
+		mw(w, r, next)
+		return w
+	}
+
+	serve("http://localhost:4000/.ory/self-service/logout?token=secret-token", http.Header{
+		"Cookie":        {"ory_session_example=secret-session; csrf=secret-csrf"},
+		"Authorization": {"Bearer secret-bearer"},
+	})
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(stdErr.Bytes(), &entry), stdErr.String())
+	assert.Equal(t, "GET", entry["method"])
+	assert.Equal(t, "http://localhost:4000/.ory/self-service/logout?token=%5Bredacted%5D", entry["url"])
+	assert.EqualValues(t, http.StatusSeeOther, entry["status"])
+	assert.Equal(t, "ory", entry["target"])
+	assert.Equal(t, "example.projects.oryapis.com", entry["upstream"])
+	assert.Equal(t, "http://localhost:4000/welcome", entry["location"])
+	assert.Equal(t, []any{"ory_session_example"}, entry["set_cookies"])
+	assert.Equal(t, true, entry["session"])
+	assert.Equal(t, true, entry["jwt"])
+	assert.Contains(t, entry, "latency_ms")
+	assert.NotContains(t, entry, "request_header", "headers are only shown in the inspector")
+
+	serve("http://localhost:4000/two", http.Header{})
+	serve("http://localhost:4000/three", http.Header{})
+
+	t.Run("case=inspector", func(t *testing.T) {
+		stdErr.Reset()
+		w := serve("http://localhost:4000/.ory/proxy/inspect?format=json", http.Header{})
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, stdErr.String(), "the inspector does not log itself")
+
+		var exchanges []exchange
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &exchanges))
+		require.Len(t, exchanges, 2)
+		assert.Equal(t, "http://localhost:4000/three", exchanges[0].URL)
+		assert.Equal(t, "http://localhost:4000/two", exchanges[1].URL)
+
+		w = serve("http://localhost:4000/.ory/proxy/inspect", http.Header{})
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, w.Body.String(), "/three")
+	})
+
+	t.Run("case=redaction", func(t *testing.T) {
+		header := redactHeader(http.Header{
+			"Cookie":                     {"ory_session_example=secret-session; csrf=secret-csrf"},
+			"Set-Cookie":                 {"ory_session_example=secret-session; Path=/; HttpOnly"},
+			"Authorization":              {"Bearer secret-bearer"},
+			"X-Session-Token":            {"secret-token"},
+			"Ory-Base-Url-Rewrite-Token": {"secret-api-key"},
+			"Accept":                     {"text/html"},
+			"Location":                   {"https://app.example.com/callback?code=secret-code&state=abc"},
+		})
+		assert.Equal(t, http.Header{
+			"Cookie":                     {"ory_session_example=[redacted]; csrf=[redacted]"},
+			"Set-Cookie":                 {"ory_session_example=[redacted]; Path=/; HttpOnly"},
+			"Authorization":              {"Bearer [redacted]"},
+			"X-Session-Token":            {"[redacted]"},
+			"Ory-Base-Url-Rewrite-Token": {"[redacted]"},
+			"Accept":                     {"text/html"},
+			"Location":                   {"https://app.example.com/callback?code=%5Bredacted%5D&state=abc"},
+		}, header)
+	})
+
+	t.Run("case=location", func(t *testing.T) {
+		var stdErr bytes.Buffer
+		log := newExchangeLog(conf)
+		mw, err := accessLogMiddleware(conf, log, &stdErr)
+		require.NoError(t, err)
+		mw(httptest.NewRecorder(), httptest.NewRequest("GET", "http://localhost:4000/.ory/self-service/recovery", nil), func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Location", "http://localhost:4000/.ory/self-service/recovery?flow=1&token=secret-token")
+			w.WriteHeader(http.StatusSeeOther)
+		})
+
+		assert.NotContains(t, stdErr.String(), "secret-token")
+		require.Len(t, log.last(), 1)
+		assert.Equal(t, "http://localhost:4000/.ory/self-service/recovery?flow=1&token=%5Bredacted%5D", log.last()[0].Location)
+		assert.NotContains(t, log.last()[0].ResponseHeader.Get("Location"), "secret-token")
+	})
+
+	t.Run("case=text", func(t *testing.T) {
+		var stdErr bytes.Buffer
+		mw, err := accessLogMiddleware(&config{logFormat: logFormatText}, nil, &stdErr)
+		require.NoError(t, err)
+		mw(httptest.NewRecorder(), httptest.NewRequest("GET", "http://localhost:4000/", nil), next)
+		assert.Contains(t, stdErr.String(), "GET [http://localhost:4000/]\n=> 303 GET [http://localhost:4000/] took")
+
+		_, err = accessLogMiddleware(&config{logFormat: "xml"}, nil, &stdErr)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,437 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	"github.com/ory/cli/buildinfo"
+	"github.com/ory/herodot"
+)
+
+const (
+	RecordFlag        = "record"
+	ReplayFlag        = "replay"
+	ReplayMatchFlag   = "replay-match"
+	replayMatchExact  = "exact"
+	replayMatchNormal = "normalized"
+)
+
+func registerCassetteFlags(conf *config, flags *pflag.FlagSet) {
+	flags.StringVar(&conf.record, RecordFlag, "", "Record the requests to Ory and their responses to this HAR file.")
+	flags.StringVar(&conf.replay, ReplayFlag, "", "Answer requests with the responses recorded in this HAR file using --"+RecordFlag+", without connecting to Ory.")
+	flags.StringVar(&conf.replayMatch, ReplayMatchFlag, replayMatchNormal, "How --"+ReplayFlag+" matches requests to recorded ones. "+replayMatchExact+" compares the method, path, query, and body, except for the redacted credentials. "+replayMatchNormal+" additionally ignores UUIDs, such as flow IDs, CSRF tokens, and credentials.")
+}
+
+// The cassettes are HTTP Archives (HAR) 1.2, so that they can be inspected
+// with the browser's developer tools. Only the fields needed for replaying
+// are filled in.
+type (
+	harFile struct {
+		Log harLog `json:"log"`
+	}
+	harLog struct {
+		Version string      `json:"version"`
+		Creator harCreator  `json:"creator"`
+		Entries []*harEntry `json:"entries"`
+	}
+	harCreator struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	harEntry struct {
+		StartedDateTime time.Time   `json:"startedDateTime"`
+		Time            float64     `json:"time"`
+		Request         harRequest  `json:"request"`
+		Response        harResponse `json:"response"`
+		Cache           struct{}    `json:"cache"`
+		Timings         harTimings  `json:"timings"`
+	}
+	harRequest struct {
+		Method      string         `json:"method"`
+		URL         string         `json:"url"`
+		HTTPVersion string         `json:"httpVersion"`
+		Cookies     []harNameValue `json:"cookies"`
+		Headers     []harNameValue `json:"headers"`
+		QueryString []harNameValue `json:"queryString"`
+		PostData    *harPostData   `json:"postData,omitempty"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int            `json:"bodySize"`
+	}
+	harResponse struct {
+		Status      int            `json:"status"`
+		StatusText  string         `json:"statusText"`
+		HTTPVersion string         `json:"httpVersion"`
+		Cookies     []harNameValue `json:"cookies"`
+		Headers     []harNameValue `json:"headers"`
+		Content     harContent     `json:"content"`
+		RedirectURL string         `json:"redirectURL"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int            `json:"bodySize"`
+	}
+	harNameValue struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+	harPostData struct {
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+	}
+	harContent struct {
+		Size     int    `json:"size"`
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+		Encoding string `json:"encoding,omitempty"`
+	}
+	harTimings struct {
+		Send    float64 `json:"send"`
+		Wait    float64 `json:"wait"`
+		Receive float64 `json:"receive"`
+	}
+)
+
+func harHeaders(h http.Header) []harNameValue {
+	headers := make([]harNameValue, 0, len(h))
+	for _, name := range slices.Sorted(maps.Keys(h)) {
+		for _, v := range h[name] {
+			headers = append(headers, harNameValue{Name: name, Value: v})
+		}
+	}
+	return headers
+}
+
+func harQuery(u *url.URL) []harNameValue {
+	q := u.Query()
+	query := make([]harNameValue, 0, len(q))
+	for name, values := range q {
+		for _, v := range values {
+			query = append(query, harNameValue{Name: name, Value: v})
+		}
+	}
+	slices.SortStableFunc(query, func(a, b harNameValue) int { return strings.Compare(a.Name, b.Name) })
+	return query
+}
+
+// harText returns the body as HAR content text, base64 encoded unless it is
+// valid UTF-8 and not compressed.
+func harText(body []byte, header http.Header) (text, encoding string) {
+	if utf8.Valid(body) && header.Get("Content-Encoding") == "" {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+func (c harContent) body() ([]byte, error) {
+	if c.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(c.Text)
+	}
+	return []byte(c.Text), nil
+}
+
+// recorder records the exchanges with Ory to a cassette. The file is written
+// after every exchange, so that it is complete whenever the tunnel is stopped.
+type recorder struct {
+	path   string
+	stdErr io.Writer
+
+	mu  sync.Mutex
+	har harFile
+}
+
+func newRecorder(path string, stdErr io.Writer) *recorder {
+	return &recorder{path: path, stdErr: stdErr, har: harFile{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: "Ory CLI", Version: buildinfo.Version},
+		Entries: []*harEntry{},
+	}}}
+}
+
+type bodyCatcher struct {
+	*responseStatusCatcher
+	body bytes.Buffer
+}
+
+func (b *bodyCatcher) Write(p []byte) (int, error) {
+	b.body.Write(p)
+	return b.responseStatusCatcher.Write(p)
+}
+
+func (rec *recorder) middleware(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	var reqBody []byte
+	if r.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	start := time.Now()
+	catcher := &bodyCatcher{responseStatusCatcher: &responseStatusCatcher{ResponseWriter: w}}
+	next(catcher, r)
+	took := float64(time.Since(start).Microseconds()) / 1000
+
+	entry := &harEntry{
+		StartedDateTime: start.UTC(),
+		Time:            took,
+		Request: harRequest{
+			Method:      r.Method,
+			URL:         r.URL.RequestURI(),
+			HTTPVersion: r.Proto,
+			Cookies:     []harNameValue{},
+			// The credentials of the request are not needed for replaying.
+			Headers:     harHeaders(redactHeader(r.Header)),
+			QueryString: harQuery(r.URL),
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Response: harResponse{
+			Status:      responseStatus(catcher.status),
+			StatusText:  http.StatusText(responseStatus(catcher.status)),
+			HTTPVersion: r.Proto,
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(redactSessionCookies(catcher.Header())),
+			RedirectURL: catcher.Header().Get("Location"),
+			HeadersSize: -1,
+			BodySize:    catcher.body.Len(),
+		},
+		Timings: harTimings{Wait: took},
+	}
+	if len(reqBody) > 0 {
+		text, _ := harText(redactBody(r.Header.Get("Content-Type"), reqBody), http.Header{})
+		entry.Request.PostData = &harPostData{MimeType: r.Header.Get("Content-Type"), Text: text}
+	}
+	entry.Response.Content.Text, entry.Response.Content.Encoding = harText(catcher.body.Bytes(), catcher.Header())
+	entry.Response.Content.Size = catcher.body.Len()
+	entry.Response.Content.MimeType = catcher.Header().Get("Content-Type")
+
+	if err := rec.add(entry); err != nil {
+		_, _ = fmt.Fprintf(rec.stdErr, "Unable to record %s %s: %s\n", r.Method, r.URL, err)
+	}
+}
+
+func (rec *recorder) add(entry *harEntry) error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.har.Log.Entries = append(rec.har.Log.Entries, entry)
+	raw, err := json.MarshalIndent(rec.har, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.WriteFile(rec.path, raw, 0600))
+}
+
+// replayer answers requests with the responses recorded in a cassette.
+// Recorded exchanges are replayed in order: each one answers one request, and
+// once all exchanges matching a request were replayed, the last one answers
+// any further such requests.
+type replayer struct {
+	match  string
+	writer herodot.Writer
+
+	mu      sync.Mutex
+	entries []*harEntry
+	used    []bool
+}
+
+func newReplayer(path, match string, writer herodot.Writer) (*replayer, error) {
+	if match != replayMatchExact && match != replayMatchNormal {
+		return nil, errors.Errorf("--%s must be either %s or %s", ReplayMatchFlag, replayMatchExact, replayMatchNormal)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the cassette")
+	}
+	var har harFile
+	if err := json.Unmarshal(raw, &har); err != nil {
+		return nil, errors.Wrapf(err, "unable to decode the cassette %s", path)
+	}
+	return &replayer{match: match, writer: writer, entries: har.Log.Entries, used: make([]bool, len(har.Log.Entries))}, nil
+}
+
+func (rp *replayer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		rp.writer.WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithWrap(err)))
+		return
+	}
+	if ex := exchangeFromContext(r.Context()); ex != nil {
+		ex.Target, ex.Upstream = targetOry, "cassette"
+	}
+	key := rp.requestKey(r.Method, r.URL, r.Header.Get("Content-Type"), body)
+
+	entry := rp.next(key)
+	if entry == nil {
+		rp.writer.WriteError(w, r, errors.WithStack(herodot.ErrNotFound().WithReasonf("No recorded response matches %s %s.", r.Method, r.URL.RequestURI())))
+		return
+	}
+
+	respBody, err := entry.Response.Content.body()
+	if err != nil {
+		rp.writer.WriteError(w, r, errors.WithStack(herodot.ErrInternalServerError().WithWrap(err)))
+		return
+	}
+	for _, h := range entry.Response.Headers {
+		// The proxy's own middlewares set these again.
+		if strings.EqualFold(h.Name, "Strict-Transport-Security") || strings.HasPrefix(strings.ToLower(h.Name), "access-control-") {
+			continue
+		}
+		w.Header().Add(h.Name, h.Value)
+	}
+	w.WriteHeader(entry.Response.Status)
+	_, _ = w.Write(respBody)
+}
+
+// next returns the recorded exchange answering the request with the key.
+func (rp *replayer) next(key string) *harEntry {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	last := -1
+	for k, e := range rp.entries {
+		u, err := url.ParseRequestURI(e.Request.URL)
+		if err != nil {
+			continue
+		}
+		var body []byte
+		if e.Request.PostData != nil {
+			body = []byte(e.Request.PostData.Text)
+		}
+		if rp.requestKey(e.Request.Method, u, harHeaderValue(e.Request.Headers, "Content-Type"), body) != key {
+			continue
+		}
+		if !rp.used[k] {
+			rp.used[k] = true
+			return e
+		}
+		last = k
+	}
+	if last >= 0 {
+		return rp.entries[last]
+	}
+	return nil
+}
+
+func harHeaderValue(headers []harNameValue, name string) string {
+	for _, h := range headers {
+		if strings.EqualFold(h.Name, name) {
+			return h.Value
+		}
+	}
+	return ""
+}
+
+var uuidPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+// redactedBodyFields carry the credentials and CSRF tokens submitted to Ory's
+// flows. They are redacted in the cassette and ignored when matching.
+var redactedBodyFields = []string{"password", "totp_code", "lookup_secret", "code", "csrf_token"}
+
+// redactBody replaces the values of the redacted fields in form and JSON
+// bodies. Other bodies are returned as they are.
+func redactBody(contentType string, body []byte) []byte {
+	switch {
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return body
+		}
+		changed := false
+		for _, name := range redactedBodyFields {
+			if form.Has(name) {
+				form.Set(name, redacted)
+				changed = true
+			}
+		}
+		if changed {
+			return []byte(form.Encode())
+		}
+	case strings.HasPrefix(contentType, "application/json"):
+		var doc map[string]any
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return body
+		}
+		changed := false
+		for _, name := range redactedBodyFields {
+			if _, ok := doc[name]; ok {
+				doc[name] = redacted
+				changed = true
+			}
+		}
+		if changed {
+			redactedBody, _ := json.Marshal(doc)
+			return redactedBody
+		}
+	}
+	return body
+}
+
+// redactSessionCookies returns a copy of the response header with the values
+// of the session cookies redacted. Other cookies, such as the CSRF cookie, are
+// needed for replaying.
+func redactSessionCookies(header http.Header) http.Header {
+	redactedHeader := header.Clone()
+	for k, v := range redactedHeader.Values("Set-Cookie") {
+		cookie, attributes, _ := strings.Cut(v, ";")
+		name, _, ok := strings.Cut(cookie, "=")
+		if !ok || !strings.Contains(strings.ToLower(name), "session") {
+			continue
+		}
+		redactedHeader["Set-Cookie"][k] = name + "=" + redacted
+		if attributes != "" {
+			redactedHeader["Set-Cookie"][k] += ";" + attributes
+		}
+	}
+	return redactedHeader
+}
+
+// requestKey returns what two requests must have in common to match.
+func (rp *replayer) requestKey(method string, u *url.URL, contentType string, body []byte) string {
+	query := u.Query()
+	if rp.match == replayMatchExact {
+		// The recorded bodies are redacted, so the requests are as well.
+		return method + " " + u.Path + "?" + query.Encode() + "\n" + string(redactBody(contentType, body))
+	}
+
+	query.Del("csrf_token")
+	normalized := method + " " + u.Path + "?" + query.Encode() + "\n"
+
+	switch {
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for _, name := range redactedBodyFields {
+				form.Del(name)
+			}
+			body = []byte(form.Encode())
+		}
+	case strings.HasPrefix(contentType, "application/json"):
+		var doc map[string]any
+		if err := json.Unmarshal(body, &doc); err == nil {
+			for _, name := range redactedBodyFields {
+				delete(doc, name)
+			}
+			// encoding/json sorts the keys, which also normalizes their order.
+			body, _ = json.Marshal(doc)
+		}
+	}
+	return uuidPattern.ReplaceAllString(normalized+string(body), "{uuid}")
+}
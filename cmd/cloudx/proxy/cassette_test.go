@@ -0,0 +1,115 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/herodot"
+)
+
+func TestCassette(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.har")
+	rec := newRecorder(cassette, io.Discard)
+
+	ory := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/self-service/login/browser":
+			http.SetCookie(w, &http.Cookie{Name: "csrf_token", Value: "recorded-csrf", Path: "/"})
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id": "5a2f0b5c-0a5d-4c4e-9c4f-6b9a1f2b3c4d"}`))
+		case "/self-service/login":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"received": ` + string(body) + `}`))
+		case "/self-service/registration":
+			http.SetCookie(w, &http.Cookie{Name: "ory_session_example", Value: "live-session", Path: "/", HttpOnly: true})
+		case "/sessions/whoami":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte{0xff, 0x00})
+		}
+	}
+	record := func(method, target, contentType, body string) {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		r.Header.Set("Cookie", "ory_session=secret-session")
+		rec.middleware(httptest.NewRecorder(), r, ory)
+	}
+	record("GET", "http://localhost:4000/self-service/login/browser", "", "")
+	record("POST", "http://localhost:4000/self-service/login?flow=5a2f0b5c-0a5d-4c4e-9c4f-6b9a1f2b3c4d", "application/json", `{"method": "password", "csrf_token": "recorded-csrf", "identifier": "foo@bar"}`)
+	record("GET", "http://localhost:4000/sessions/whoami", "", "")
+	record("GET", "http://localhost:4000/sessions/whoami", "", "")
+	record("POST", "http://localhost:4000/self-service/registration?flow=7c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f", "application/x-www-form-urlencoded", "method=password&identifier=foo%40bar&password=hunter2&csrf_token=recorded-csrf")
+
+	raw, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "secret-session", "the credentials of requests are not recorded")
+	assert.NotContains(t, string(raw), "hunter2", "passwords are redacted")
+	assert.NotContains(t, string(raw), "live-session", "session cookies are redacted")
+	assert.Contains(t, string(raw), "ory_session_example=[redacted]; Path=/; HttpOnly")
+
+	replay := func(t *testing.T, match, method, target, contentType, body string) *httptest.ResponseRecorder {
+		rp, err := newReplayer(cassette, match, herodot.NewJSONWriter(nil))
+		require.NoError(t, err)
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		rp.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("case=normalized", func(t *testing.T) {
+		w := replay(t, replayMatchNormal, "POST", "http://localhost:4000/self-service/login?flow=0b7e6a4e-3b9a-4a51-8d0e-2f6c1d9e8a7b", "application/json", `{"identifier": "foo@bar", "csrf_token": "other-csrf", "method": "password"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"identifier": "foo@bar"`)
+
+		w = replay(t, replayMatchNormal, "GET", "http://localhost:4000/self-service/login/browser", "", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Values("Set-Cookie")[0], "recorded-csrf")
+
+		w = replay(t, replayMatchNormal, "POST", "http://localhost:4000/self-service/login?flow=0b7e6a4e-3b9a-4a51-8d0e-2f6c1d9e8a7b", "application/json", `{"identifier": "other@bar"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = replay(t, replayMatchNormal, "POST", "http://localhost:4000/self-service/registration?flow=0b7e6a4e-3b9a-4a51-8d0e-2f6c1d9e8a7b", "application/x-www-form-urlencoded", "csrf_token=other-csrf&identifier=foo%40bar&method=password&password=other-password")
+		assert.Equal(t, http.StatusOK, w.Code, "credentials are ignored")
+	})
+
+	t.Run("case=exact", func(t *testing.T) {
+		w := replay(t, replayMatchExact, "POST", "http://localhost:4000/self-service/login?flow=0b7e6a4e-3b9a-4a51-8d0e-2f6c1d9e8a7b", "application/json", `{"method": "password", "csrf_token": "recorded-csrf", "identifier": "foo@bar"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = replay(t, replayMatchExact, "POST", "http://localhost:4000/self-service/login?flow=5a2f0b5c-0a5d-4c4e-9c4f-6b9a1f2b3c4d", "application/json", `{"method": "password", "csrf_token": "recorded-csrf", "identifier": "foo@bar"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = replay(t, replayMatchExact, "POST", "http://localhost:4000/self-service/registration?flow=7c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f", "application/x-www-form-urlencoded", "method=password&identifier=foo%40bar&password=hunter2&csrf_token=recorded-csrf")
+		assert.Equal(t, http.StatusOK, w.Code, "the redacted credentials match")
+	})
+
+	t.Run("case=order", func(t *testing.T) {
+		rp, err := newReplayer(cassette, replayMatchNormal, herodot.NewJSONWriter(nil))
+		require.NoError(t, err)
+		for range 3 {
+			w := httptest.NewRecorder()
+			rp.ServeHTTP(w, httptest.NewRequest("GET", "http://localhost:4000/sessions/whoami", nil))
+			assert.Equal(t, http.StatusUnauthorized, w.Code, "the last matching exchange is replayed repeatedly")
+			assert.True(t, bytes.Equal([]byte{0xff, 0x00}, w.Body.Bytes()), "binary bodies are kept")
+		}
+		assert.Equal(t, []bool{false, false, true, true, false}, rp.used)
+	})
+
+	t.Run("case=invalid strategy", func(t *testing.T) {
+		_, err := newReplayer(cassette, "fuzzy", herodot.NewJSONWriter(nil))
+		assert.ErrorContains(t, err, "--replay-match")
+	})
+}
@@ -0,0 +1,303 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ory/x/watcherx"
+)
+
+const ConfigFileFlag = "config-file"
+
+// PathPrefixKey is the key of the path prefix in the configuration file. The
+// path prefix has no flag.
+const PathPrefixKey = "path-prefix"
+
+func registerConfigFileFlags(conf *config, flags *pflag.FlagSet) {
+	flags.StringVar(&conf.configFile, ConfigFileFlag, "", "Read the configuration from this YAML file, whose keys are the names of the flags. Flags take precedence over the file. Changes to the file are applied without restarting.")
+}
+
+// configFile is the content of --config-file. Absent keys are nil.
+type configFile struct {
+	Port                  *int           `yaml:"port"`
+	Open                  *bool          `yaml:"open"`
+	NoJWT                 *bool          `yaml:"no-jwt"`
+	CookieDomain          *string        `yaml:"cookie-domain"`
+	DefaultRedirectURL    *string        `yaml:"default-redirect-url"`
+	PathPrefix            *string        `yaml:"path-prefix"`
+	AllowedCORSOrigins    []string       `yaml:"allowed-cors-origins"`
+	AdditionalCORSHeaders []string       `yaml:"additional-cors-headers"`
+	Debug                 *bool          `yaml:"debug"`
+	Dev                   *bool          `yaml:"dev"`
+	RewriteHost           *bool          `yaml:"rewrite-host"`
+	APIKeyExpiry          *time.Duration `yaml:"api-key-expiry"`
+	TLS                   *bool          `yaml:"tls"`
+	TLSCert               *string        `yaml:"tls-cert"`
+	TLSKey                *string        `yaml:"tls-key"`
+	JWTJWKS               *string        `yaml:"jwt-jwks"`
+	JWTAlgorithm          *string        `yaml:"jwt-algorithm"`
+	JWTTTL                *time.Duration `yaml:"jwt-ttl"`
+	JWTAudience           []string       `yaml:"jwt-audience"`
+	JWTClaims             *string        `yaml:"jwt-claims"`
+	JWTClaim              []string       `yaml:"jwt-claim"`
+	SessionCacheTTL       *time.Duration `yaml:"session-cache-ttl"`
+	LogFormat             *string        `yaml:"log-format"`
+	Inspect               *bool          `yaml:"inspect"`
+	InspectSize           *int           `yaml:"inspect-size"`
+	Metrics               *bool          `yaml:"metrics"`
+	Route                 []string       `yaml:"route"`
+	RoutesFile            *string        `yaml:"routes-file"`
+	MockSession           *string        `yaml:"mock-session"`
+	Record                *string        `yaml:"record"`
+	Replay                *string        `yaml:"replay"`
+	ReplayMatch           *string        `yaml:"replay-match"`
+}
+
+// setter applies a value of the file to the configuration, if it is present.
+type setter func(name string, present bool, apply func() error)
+
+func setValue[T any](set setter, name string, value *T, target *T) {
+	set(name, value != nil, func() error {
+		*target = *value
+		return nil
+	})
+}
+
+func setList(set setter, name string, value []string, target *[]string) {
+	set(name, value != nil, func() error {
+		*target = slices.Clone(value)
+		return nil
+	})
+}
+
+// apply sets the values of the file on the configuration, unless the flag of
+// a value was set.
+func (f *configFile) apply(conf *config) (err error) {
+	set := func(name string, present bool, apply func() error) {
+		if !present || err != nil {
+			return
+		}
+		if conf.flags != nil {
+			if conf.flags.Lookup(name) == nil {
+				err = errors.Errorf("--%s: %s is not supported by this command", ConfigFileFlag, name)
+				return
+			}
+			if conf.flags.Changed(name) {
+				return
+			}
+		}
+		if applyErr := apply(); applyErr != nil {
+			err = errors.Wrapf(applyErr, "--%s: invalid %s", ConfigFileFlag, name)
+		}
+	}
+
+	setValue(set, PortFlag, f.Port, &conf.port)
+	setValue(set, OpenFlag, f.Open, &conf.open)
+	setValue(set, WithoutJWTFlag, f.NoJWT, &conf.noJWT)
+	setValue(set, CookieDomainFlag, f.CookieDomain, &conf.cookieDomain)
+	set(DefaultRedirectURLFlag, f.DefaultRedirectURL != nil, func() error {
+		return conf.defaultRedirectTo.Set(*f.DefaultRedirectURL)
+	})
+	setList(set, CORSFlag, f.AllowedCORSOrigins, &conf.corsOrigins)
+	setList(set, AdditionalCORSHeadersFlag, f.AdditionalCORSHeaders, &conf.additionalCorsHeaders)
+	setValue(set, DebugFlag, f.Debug, &conf.isDebug)
+	setValue(set, DevFlag, f.Dev, &conf.isDev)
+	setValue(set, RewriteHostFlag, f.RewriteHost, &conf.rewriteHost)
+	setValue(set, APIKeyExpiryFlag, f.APIKeyExpiry, &conf.apiKeyExpiry)
+	setValue(set, TLSFlag, f.TLS, &conf.tls)
+	setValue(set, TLSCertFlag, f.TLSCert, &conf.tlsCert)
+	setValue(set, TLSKeyFlag, f.TLSKey, &conf.tlsKey)
+	setValue(set, JWTJWKSFlag, f.JWTJWKS, &conf.jwtJWKS)
+	setValue(set, JWTAlgorithmFlag, f.JWTAlgorithm, &conf.jwtAlgorithm)
+	setValue(set, JWTTTLFlag, f.JWTTTL, &conf.jwtTTL)
+	setList(set, JWTAudienceFlag, f.JWTAudience, &conf.jwtAudience)
+	setValue(set, JWTClaimsFlag, f.JWTClaims, &conf.jwtClaims)
+	setList(set, JWTClaimFlag, f.JWTClaim, &conf.jwtClaimPointers)
+	setValue(set, SessionCacheTTLFlag, f.SessionCacheTTL, &conf.sessionCacheTTL)
+	setValue(set, LogFormatFlag, f.LogFormat, &conf.logFormat)
+	setValue(set, InspectFlag, f.Inspect, &conf.inspect)
+	setValue(set, InspectSizeFlag, f.InspectSize, &conf.inspectSize)
+	setValue(set, MetricsFlag, f.Metrics, &conf.metrics)
+	setList(set, RouteFlag, f.Route, &conf.routeFlags)
+	setValue(set, RoutesFileFlag, f.RoutesFile, &conf.routesFile)
+	setValue(set, MockSessionFlag, f.MockSession, &conf.mockSession)
+	setValue(set, RecordFlag, f.Record, &conf.record)
+	setValue(set, ReplayFlag, f.Replay, &conf.replay)
+	setValue(set, ReplayMatchFlag, f.ReplayMatch, &conf.replayMatch)
+	if err != nil {
+		return err
+	}
+
+	if f.PathPrefix != nil {
+		if conf.isTunnel {
+			return errors.Errorf("--%s: %s is not supported by this command", ConfigFileFlag, PathPrefixKey)
+		}
+		prefix := "/" + strings.Trim(*f.PathPrefix, "/")
+		if prefix == "/" {
+			return errors.Errorf("--%s: %s must not be empty", ConfigFileFlag, PathPrefixKey)
+		}
+		conf.pathPrefix = prefix
+	}
+	return nil
+}
+
+func readConfigFile(file string) (*configFile, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read --%s", ConfigFileFlag)
+	}
+	var f configFile
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errors.Wrapf(err, "unable to decode --%s", ConfigFileFlag)
+	}
+	return &f, nil
+}
+
+// loadConfigFile applies --config-file to the configuration. It keeps a copy
+// of the configuration from the flags, to which later changes of the file
+// are applied.
+func loadConfigFile(conf *config) error {
+	if conf.configFile == "" {
+		return nil
+	}
+	fromFlags := *conf
+	conf.fromFlags = &fromFlags
+
+	f, err := readConfigFile(conf.configFile)
+	if err != nil {
+		return err
+	}
+	return f.apply(conf)
+}
+
+// reloadFile returns the configuration from the flags with the file applied,
+// and the names of the settings whose changes were ignored. Settings used when
+// starting are kept, as they can not be changed while running.
+func (c *config) reloadFile() (*config, []string, error) {
+	f, err := readConfigFile(c.configFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	next := *c.fromFlags
+	next.fromFlags, next.fallbackRedirectTo = c.fromFlags, c.fallbackRedirectTo
+	if err := f.apply(&next); err != nil {
+		return nil, nil, err
+	}
+	next.publicURL, next.upstream = c.publicURL, c.upstream
+	if next.defaultRedirectTo.String() == "" {
+		next.defaultRedirectTo = c.fallbackRedirectTo
+	}
+
+	var ignored []string
+	keep := func(name string, changed bool) {
+		if changed {
+			ignored = append(ignored, name)
+		}
+	}
+	keep(PortFlag, next.port != c.port)
+	keep(OpenFlag, next.open != c.open)
+	keep(APIKeyExpiryFlag, next.apiKeyExpiry != c.apiKeyExpiry)
+	keep(TLSFlag, next.tls != c.tls)
+	keep(TLSCertFlag, next.tlsCert != c.tlsCert)
+	keep(TLSKeyFlag, next.tlsKey != c.tlsKey)
+	keep(JWTJWKSFlag, next.jwtJWKS != c.jwtJWKS)
+	keep(JWTAlgorithmFlag, next.jwtAlgorithm != c.jwtAlgorithm)
+	keep(InspectFlag, next.inspect != c.inspect)
+	keep(InspectSizeFlag, next.inspectSize != c.inspectSize)
+	keep(MetricsFlag, next.metrics != c.metrics)
+	keep(MockSessionFlag, next.mockSession != c.mockSession)
+	keep(RecordFlag, next.record != c.record)
+	keep(ReplayFlag, next.replay != c.replay)
+	keep(ReplayMatchFlag, next.replayMatch != c.replayMatch)
+	next.port, next.open, next.apiKeyExpiry = c.port, c.open, c.apiKeyExpiry
+	next.tls, next.tlsCert, next.tlsKey = c.tls, c.tlsCert, c.tlsKey
+	next.jwtJWKS, next.jwtAlgorithm = c.jwtJWKS, c.jwtAlgorithm
+	next.inspect, next.inspectSize, next.metrics, next.mockSession = c.inspect, c.inspectSize, c.metrics, c.mockSession
+	next.record, next.replay, next.replayMatch = c.record, c.replay, c.replayMatch
+
+	return &next, ignored, nil
+}
+
+// liveHandler serves requests with the handler built from the latest
+// configuration. Requests in flight finish with the handler they started with.
+type liveHandler struct {
+	current atomic.Pointer[http.Handler]
+}
+
+func (l *liveHandler) set(h http.Handler) {
+	l.current.Store(&h)
+}
+
+func (l *liveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(*l.current.Load()).ServeHTTP(w, r)
+}
+
+// reloadDelay is how long to wait for further changes before reloading, as
+// editors often write a file in several steps.
+const reloadDelay = 100 * time.Millisecond
+
+// watchConfigFile rebuilds the handler whenever the configuration file
+// changes. An invalid configuration is reported, and the previous one kept.
+func watchConfigFile(ctx context.Context, conf *config, stdErr io.Writer, live *liveHandler, build func(*config) (http.Handler, error)) error {
+	events := make(watcherx.EventChannel)
+	if _, err := watcherx.WatchFile(ctx, conf.configFile, events); err != nil {
+		return errors.Wrapf(err, "unable to watch --%s", ConfigFileFlag)
+	}
+
+	go func() {
+		current := conf
+		reload := time.NewTimer(0)
+		<-reload.C
+		for {
+			select {
+			case <-ctx.Done():
+				reload.Stop()
+				return
+			case e := <-events:
+				switch e := e.(type) {
+				case *watcherx.ErrorEvent:
+					_, _ = fmt.Fprintf(stdErr, "Unable to watch %s: %s\n", conf.configFile, e)
+				case *watcherx.RemoveEvent:
+					_, _ = fmt.Fprintf(stdErr, "%s was removed, keeping the current configuration.\n", conf.configFile)
+				default:
+					reload.Reset(reloadDelay)
+				}
+			case <-reload.C:
+				next, ignored, err := current.reloadFile()
+				if err == nil {
+					var h http.Handler
+					if h, err = build(next); err == nil {
+						live.set(h)
+						current = next
+					}
+				}
+				if err != nil {
+					_, _ = fmt.Fprintf(stdErr, "Unable to apply %s, keeping the current configuration: %s\n", conf.configFile, err)
+					continue
+				}
+				_, _ = fmt.Fprintf(stdErr, "Applied %s.\n", conf.configFile)
+				if len(ignored) > 0 {
+					_, _ = fmt.Fprintf(stdErr, "Restart to apply the changes of: %s\n", strings.Join(ignored, ", "))
+				}
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,208 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/herodot"
+)
+
+func TestConfigFile(t *testing.T) {
+	newConfig := func(t *testing.T, file string, args ...string) *config {
+		conf := &config{pathPrefix: "/.ory"}
+		flags := pflag.NewFlagSet("proxy", pflag.ContinueOnError)
+		registerConfigFlags(conf, flags)
+		registerProxyConfigFlags(conf, flags)
+		require.NoError(t, flags.Parse(append(args, "--"+ConfigFileFlag, file)))
+		return conf
+	}
+	writeFile := func(t *testing.T, file, content string) {
+		require.NoError(t, os.WriteFile(file, []byte(content), 0600))
+	}
+
+	t.Run("case=flags take precedence", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "proxy.yaml")
+		writeFile(t, file, `
+port: 5000
+cookie-domain: example.org
+allowed-cors-origins: [https://www.example.org]
+additional-cors-headers: [X-Custom]
+rewrite-host: true
+default-redirect-url: http://localhost:4000/welcome
+path-prefix: /auth/
+jwt-ttl: 5m
+`)
+		conf := newConfig(t, file, "--"+PortFlag, "4001")
+		require.NoError(t, loadConfigFile(conf))
+
+		assert.Equal(t, 4001, conf.port)
+		assert.Equal(t, "example.org", conf.cookieDomain)
+		assert.Equal(t, []string{"https://www.example.org"}, conf.corsOrigins)
+		assert.Equal(t, []string{"X-Custom"}, conf.additionalCorsHeaders)
+		assert.True(t, conf.rewriteHost)
+		assert.Equal(t, "http://localhost:4000/welcome", conf.defaultRedirectTo.String())
+		assert.Equal(t, "/auth", conf.pathPrefix)
+		assert.Equal(t, 5*time.Minute, conf.jwtTTL)
+		assert.Equal(t, defaultSessionCacheTTL, conf.sessionCacheTTL, "defaults are kept")
+	})
+
+	t.Run("case=invalid", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "proxy.yaml")
+		for content, msg := range map[string]string{
+			"cors-origins: [a]":          "field cors-origins not found",
+			"record: cassette":           "record is not supported by this command",
+			"path-prefix: /":             "path-prefix must not be empty",
+			"jwt-ttl: forever":           "into time.Duration",
+			"default-redirect-url: '::'": "invalid default-redirect-url",
+		} {
+			writeFile(t, file, content)
+			assert.ErrorContains(t, loadConfigFile(newConfig(t, file)), msg, content)
+		}
+	})
+
+	t.Run("case=reload", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "proxy.yaml")
+		writeFile(t, file, "allowed-cors-origins: [https://www.example.org]\nport: 5000\n")
+		conf := newConfig(t, file)
+		conf.upstream = "http://localhost:3000"
+		require.NoError(t, loadConfigFile(conf))
+		fallback, err := url.Parse("http://localhost:5000")
+		require.NoError(t, err)
+		conf.fallbackRedirectTo.URL = *fallback
+		conf.defaultRedirectTo = conf.fallbackRedirectTo
+
+		writeFile(t, file, "cookie-domain: example.org\nport: 6000\n")
+		next, ignored, err := conf.reloadFile()
+		require.NoError(t, err)
+		assert.Empty(t, next.corsOrigins, "removed keys fall back to the flags")
+		assert.Equal(t, "example.org", next.cookieDomain)
+		assert.Equal(t, 5000, next.port, "the port can not change while running")
+		assert.Equal(t, []string{PortFlag}, ignored)
+		assert.Equal(t, "http://localhost:5000", next.defaultRedirectTo.String())
+		assert.Equal(t, conf.upstream, next.upstream)
+
+		writeFile(t, file, "default-redirect-url: http://localhost:5000/welcome\n")
+		next, _, err = next.reloadFile()
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost:5000/welcome", next.defaultRedirectTo.String())
+	})
+
+	t.Run("case=reload the proxy", func(t *testing.T) {
+		upstream := func(name string) string {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = io.WriteString(w, name)
+			}))
+			t.Cleanup(ts.Close)
+			return ts.URL
+		}
+		app, api := upstream("app"), upstream("api")
+
+		file := filepath.Join(t.TempDir(), "proxy.yaml")
+		writeFile(t, file, "cookie-domain: example.org\n")
+		conf := newConfig(t, file, "--"+WithoutJWTFlag)
+		conf.upstream = app
+		require.NoError(t, loadConfigFile(conf))
+		conf.publicURL, _ = url.Parse("http://localhost:4000")
+
+		oryURL, err := url.Parse(upstream("ory"))
+		require.NoError(t, err)
+		state := &proxyState{stdErr: io.Discard, writer: herodot.NewJSONWriter(nil), oryURL: oryURL}
+		h, err := newProxyHandler(conf, state)
+		require.NoError(t, err)
+		live := new(liveHandler)
+		live.set(h)
+		get := func(path string) string {
+			w := httptest.NewRecorder()
+			live.ServeHTTP(w, httptest.NewRequest("GET", "http://localhost:4000"+path, nil))
+			return w.Body.String()
+		}
+		assert.Equal(t, "app", get("/api/users"))
+
+		var stdErr syncBuffer
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		require.NoError(t, watchConfigFile(ctx, conf, &stdErr, live, func(conf *config) (http.Handler, error) {
+			return newProxyHandler(conf, state)
+		}))
+
+		writeFile(t, file, "cookie-domain: example.org\nroute: [/api="+api+"]\n")
+		assert.Eventually(t, func() bool { return strings.Contains(stdErr.String(), "Applied") }, 5*time.Second, 10*time.Millisecond)
+		assert.NotContains(t, stdErr.String(), "keeping the current configuration")
+		assert.Equal(t, "api", get("/api/users"))
+		assert.Equal(t, "app", get("/"), "the application is still the default upstream")
+	})
+
+	t.Run("case=watch", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "proxy.yaml")
+		writeFile(t, file, "cookie-domain: a.example.org\n")
+		conf := newConfig(t, file)
+		require.NoError(t, loadConfigFile(conf))
+
+		build := func(conf *config) (http.Handler, error) {
+			if conf.cookieDomain == "invalid" {
+				return nil, assert.AnError
+			}
+			return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = io.WriteString(w, conf.cookieDomain)
+			}), nil
+		}
+		live := new(liveHandler)
+		h, err := build(conf)
+		require.NoError(t, err)
+		live.set(h)
+
+		get := func() string {
+			w := httptest.NewRecorder()
+			live.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+			return w.Body.String()
+		}
+		assert.Equal(t, "a.example.org", get())
+
+		var stdErr syncBuffer
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		require.NoError(t, watchConfigFile(ctx, conf, &stdErr, live, build))
+
+		writeFile(t, file, "cookie-domain: b.example.org\n")
+		assert.Eventually(t, func() bool { return get() == "b.example.org" }, 5*time.Second, 10*time.Millisecond)
+
+		writeFile(t, file, "cookie-domain: invalid\n")
+		assert.Eventually(t, func() bool { return strings.Contains(stdErr.String(), "keeping the current configuration") }, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, "b.example.org", get())
+	})
+}
+
+// syncBuffer is a buffer written to by the watcher while the test reads it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
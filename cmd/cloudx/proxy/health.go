@@ -0,0 +1,114 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	alivePath = "/proxy/health/alive"
+	readyPath = "/proxy/health/ready"
+)
+
+// readinessTimeout bounds the requests to Ory when checking readiness, so
+// that a health check gets an answer before it times out itself.
+const readinessTimeout = 5 * time.Second
+
+func errNotReady() *herodot.DefaultError {
+	return &herodot.DefaultError{
+		IDField:     "not_ready",
+		StatusField: http.StatusText(http.StatusServiceUnavailable),
+		ErrorField:  "The proxy is not ready",
+		CodeField:   http.StatusServiceUnavailable,
+	}
+}
+
+type healthStatus struct {
+	Status string `json:"status"`
+}
+
+// checkReadiness verifies that the project answers, and that it accepts the
+// API key if there is one.
+func checkReadiness(ctx context.Context, c *http.Client, state *proxyState) error {
+	if state.oryURL == nil {
+		// Replaying does not need Ory.
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	get := func(path, query string, authorize bool) (int, error) {
+		u := urlx.AppendPaths(state.oryURL, path)
+		u.RawQuery = query
+		req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		req.Header.Set("Accept", "application/json")
+		if authorize {
+			req.Header.Set("Authorization", "Bearer "+state.apiKey)
+		}
+		res, err := c.Do(req)
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		_ = res.Body.Close()
+		return res.StatusCode, nil
+	}
+
+	status, err := get("/sessions/whoami", "", false)
+	if err != nil {
+		return errors.Wrap(err, "Ory is not reachable")
+	}
+	if status != http.StatusOK && status != http.StatusUnauthorized {
+		return errors.Errorf("Ory responded with status %d", status)
+	}
+
+	if state.apiKey == "" {
+		return nil
+	}
+	switch status, err := get("/admin/identities", "page_size=1", true); {
+	case err != nil:
+		return errors.Wrap(err, "Ory is not reachable")
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return errors.New("the API key was not accepted")
+	case status != http.StatusOK:
+		return errors.Errorf("Ory responded with status %d to the API key", status)
+	}
+	return nil
+}
+
+// healthMiddleware serves the health checks and metrics. They are answered
+// before the access log, so that frequent checks do not fill it up.
+func healthMiddleware(conf *config, state *proxyState) func(http.ResponseWriter, *http.Request, http.HandlerFunc) {
+	hc := &http.Client{Timeout: readinessTimeout}
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		switch r.URL.Path {
+		case conf.proxyPath(alivePath):
+			state.writer.Write(w, r, &healthStatus{Status: "ok"})
+		case conf.proxyPath(readyPath):
+			if err := checkReadiness(r.Context(), hc, state); err != nil {
+				state.writer.WriteError(w, r, errors.WithStack(errNotReady().WithReasonf("The proxy is not ready: %s", err).WithWrap(err)))
+				return
+			}
+			state.writer.Write(w, r, &healthStatus{Status: "ok"})
+		case conf.proxyPath(metricsPath):
+			if state.metrics == nil {
+				next(w, r)
+				return
+			}
+			state.metrics.handler().ServeHTTP(w, r)
+		default:
+			next(w, r)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/herodot"
+)
+
+func TestHealth(t *testing.T) {
+	var acceptKey bool
+	ory := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sessions/whoami":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/admin/identities":
+			if !acceptKey || r.Header.Get("Authorization") != "Bearer api-key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	t.Cleanup(ory.Close)
+	oryURL, err := url.Parse(ory.URL)
+	require.NoError(t, err)
+
+	conf := &config{pathPrefix: "/.ory", metrics: true, logFormat: logFormatText}
+	state := &proxyState{
+		stdErr:  io.Discard,
+		writer:  herodot.NewJSONWriter(nil),
+		oryURL:  oryURL,
+		apiKey:  "api-key",
+		metrics: newProxyMetrics(conf),
+	}
+	mw := healthMiddleware(conf, state)
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mw(w, httptest.NewRequest("GET", "http://localhost:4000"+path, nil), func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, serve("/.ory/proxy/health/alive").Code)
+
+	w := serve("/.ory/proxy/health/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "the API key was not accepted")
+
+	acceptKey = true
+	assert.Equal(t, http.StatusOK, serve("/.ory/proxy/health/ready").Code)
+
+	ory.Close()
+	w = serve("/.ory/proxy/health/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "Ory is not reachable")
+
+	assert.Equal(t, http.StatusTeapot, serve("/").Code)
+
+	t.Run("case=tunnel", func(t *testing.T) {
+		mw := healthMiddleware(&config{isTunnel: true}, &proxyState{writer: herodot.NewJSONWriter(nil)})
+		w := httptest.NewRecorder()
+		mw(w, httptest.NewRequest("GET", "http://localhost:4000/.ory/proxy/health/ready", nil), nil)
+		assert.Equal(t, http.StatusOK, w.Code, "replaying is always ready")
+	})
+
+	t.Run("case=metrics", func(t *testing.T) {
+		next := func(w http.ResponseWriter, r *http.Request) {
+			exchangeFromContext(r.Context()).Target = targetUpstream
+			w.WriteHeader(http.StatusNotFound)
+		}
+		log, err := accessLogMiddleware(conf, nil, io.Discard)
+		require.NoError(t, err)
+		log(httptest.NewRecorder(), httptest.NewRequest("POST", "http://localhost:4000/", nil), func(w http.ResponseWriter, r *http.Request) {
+			state.metrics.middleware(w, r, next)
+		})
+		state.metrics.observeSessionLookup(time.Now(), assert.AnError)
+
+		body := serve("/.ory/proxy/metrics").Body.String()
+		assert.Contains(t, body, `ory_proxy_requests_total{code="404",method="POST",target="upstream"} 1`)
+		assert.Contains(t, body, `ory_proxy_request_duration_seconds_count{target="upstream"} 1`)
+		assert.Contains(t, body, `ory_proxy_session_lookup_duration_seconds_count 1`)
+		assert.Contains(t, body, `ory_proxy_session_lookup_errors_total 1`)
+
+		assert.Equal(t, http.StatusTeapot, func() int {
+			w := httptest.NewRecorder()
+			healthMiddleware(&config{}, &proxyState{})(w, httptest.NewRequest("GET", "http://localhost:4000/.ory/proxy/metrics", nil), func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			})
+			return w.Code
+		}(), "metrics are disabled by default")
+	})
+}
@@ -5,6 +5,7 @@ package proxy
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
@@ -37,7 +39,6 @@ import (
 	"github.com/ory/herodot"
 	"github.com/ory/x/corsx"
 	"github.com/ory/x/httpx"
-	"github.com/ory/x/jwksx"
 	"github.com/ory/x/proxy"
 	"github.com/ory/x/urlx"
 )
@@ -80,6 +81,52 @@ type config struct {
 	// automatically should that cleanup fail. A value of zero disables expiry.
 	apiKeyExpiry time.Duration
 
+	// tls serves HTTPS using a certificate issued by the local certificate
+	// authority, unless tlsCert and tlsKey are set.
+	tls             bool
+	tlsCert, tlsKey string
+	tlsPrintCA      bool
+
+	// jwt* configure the JWT created from the Ory Session.
+	jwtJWKS, jwtAlgorithm string
+	jwtTTL                time.Duration
+	jwtAudience           []string
+	jwtClaims             string
+	jwtClaimPointers      []string
+
+	// sessionCacheTTL is how long the session of a request is cached.
+	sessionCacheTTL time.Duration
+
+	// logFormat is the format of the access log, and inspect enables the
+	// inspector keeping the last inspectSize exchanges.
+	logFormat   string
+	inspect     bool
+	inspectSize int
+
+	// record writes the exchanges with Ory to a cassette, and replay answers
+	// requests from one instead of Ory.
+	record, replay, replayMatch string
+
+	// metrics serves Prometheus metrics.
+	metrics bool
+
+	// mockSession is the file with the identities to create synthetic
+	// sessions for, instead of looking up the session at Ory.
+	mockSession string
+
+	// configFile is watched and applied on top of fromFlags, the
+	// configuration from the flags. flags tells which flags were set, as they
+	// take precedence over the file. fallbackRedirectTo is the redirect URL
+	// used if neither sets one.
+	configFile         string
+	flags              *pflag.FlagSet
+	fromFlags          *config
+	fallbackRedirectTo cmdx.URL
+
+	// routes forward requests to other upstreams than the application URL.
+	routeFlags []string
+	routesFile string
+
 	// rewriteHost means the host header will be rewritten to the upstream host.
 	// This is useful in cases where upstream resolves requests based on Host.
 	rewriteHost bool
@@ -88,6 +135,10 @@ type config struct {
 func registerProxyConfigFlags(conf *config, flags *pflag.FlagSet) {
 	flags.BoolVar(&conf.open, OpenFlag, false, "Open the browser when the proxy starts.")
 	flags.BoolVar(&conf.noJWT, WithoutJWTFlag, false, "Do not create a JWT from the Ory Session. Useful if you need fast start up times of the Ory Proxy.")
+	registerJWTFlags(conf, flags)
+	registerSessionCacheFlags(conf, flags)
+	registerRouteFlags(conf, flags)
+	registerMockSessionFlags(conf, flags)
 }
 
 func registerConfigFlags(conf *config, flags *pflag.FlagSet) {
@@ -100,6 +151,20 @@ func registerConfigFlags(conf *config, flags *pflag.FlagSet) {
 	flags.BoolVar(&conf.isDebug, DebugFlag, false, "Use this flag to debug, for example, CORS requests.")
 	flags.BoolVar(&conf.rewriteHost, RewriteHostFlag, false, "Use this flag to rewrite the host header to the upstream host.")
 	flags.DurationVar(&conf.apiKeyExpiry, APIKeyExpiryFlag, defaultAPIKeyExpiry, "Sets the expiry of the temporary API key the Ory CLI creates to configure your project. The key is deleted on shutdown; this expiry ensures it is removed automatically if that cleanup fails. Set to 0 to disable expiry.")
+	registerTLSFlags(conf, flags)
+	registerAccessLogFlags(conf, flags)
+	registerMetricsFlags(conf, flags)
+	registerConfigFileFlags(conf, flags)
+	conf.flags = flags
+}
+
+// proxyPath returns the path of an endpoint of the proxy itself. The tunnel
+// has no path prefix, so its endpoints are below /.ory as well.
+func (c *config) proxyPath(p string) string {
+	if c.pathPrefix == "" {
+		return path.Join("/.ory", p)
+	}
+	return path.Join(c.pathPrefix, p)
 }
 
 func portFromEnv() int {
@@ -120,107 +185,116 @@ func (r *responseStatusCatcher) WriteHeader(status int) {
 	r.ResponseWriter.WriteHeader(status)
 }
 
+// Unwrap lets http.ResponseController flush and hijack the connection.
+func (r *responseStatusCatcher) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 func runReverseProxy(ctx context.Context, h *client.CommandHelper, stdErr io.Writer, conf *config, name string) error {
-	signer, key, err := newJWTSigner()
-	if err != nil {
-		return err
+	var cert *tls.Certificate
+	if conf.useTLS() {
+		// Load the certificate first, so that a wrong path fails before
+		// anything is changed in the project.
+		var err error
+		if cert, err = tlsCertificate(conf, caDir(h), certificateHosts(conf)); err != nil {
+			return err
+		}
 	}
 
-	apiKey, removeAPIKey, err := h.TemporaryAPIKey(ctx, fmt.Sprintf("Ory %s temporary API key - %s", name, h.UserName(ctx)), conf.apiKeyExpiry)
+	signer, keys, err := newJWTSigner(conf)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if err := removeAPIKey(); err != nil {
-			_, _ = fmt.Fprintf(stdErr, "unable to remove temporary API key, please remove it manually: %s\n", err)
+
+	var (
+		apiKey string
+		oryURL *url.URL
+		replay *replayer
+	)
+	if conf.replay != "" && conf.record != "" {
+		return errors.Errorf("--%s and --%s can not be used together", RecordFlag, ReplayFlag)
+	} else if conf.replay != "" {
+		// Replaying needs neither the project nor any connection to Ory.
+		if replay, err = newReplayer(conf.replay, conf.replayMatch, herodot.NewJSONWriter(&errorLogger{Writer: stdErr})); err != nil {
+			return err
+		}
+	} else {
+		var removeAPIKey func() error
+		apiKey, removeAPIKey, err = h.TemporaryAPIKey(ctx, fmt.Sprintf("Ory %s temporary API key - %s", name, h.UserName(ctx)), conf.apiKeyExpiry)
+		if err != nil {
+			return err
 		}
-	}()
+		defer func() {
+			if err := removeAPIKey(); err != nil {
+				_, _ = fmt.Fprintf(stdErr, "unable to remove temporary API key, please remove it manually: %s\n", err)
+			}
+		}()
 
-	project, err := h.GetSelectedProject(ctx)
-	if err != nil {
-		return err
+		project, err := h.GetSelectedProject(ctx)
+		if err != nil {
+			return err
+		}
+		oryURL = client.CloudAPIsURL(project.Slug + ".projects")
+		oryURL.Host = strings.TrimSuffix(oryURL.Host, ":443")
 	}
-	oryURL := client.CloudAPIsURL(project.Slug + ".projects")
-	oryURL.Host = strings.TrimSuffix(oryURL.Host, ":443")
 
-	rateLimitName, rateLimitValue, _ := client.RateLimitHeader()
-
-	writer := herodot.NewJSONWriter(&errorLogger{Writer: stdErr})
-	mw := negroni.New()
-
-	mw.UseFunc(func(w http.ResponseWriter, r *http.Request, n http.HandlerFunc) {
-		start := time.Now()
-		_, _ = fmt.Fprintf(stdErr, "%s [%s]\n", r.Method, r.URL)
-		statusCatcher := &responseStatusCatcher{ResponseWriter: w}
-		n(statusCatcher, r)
-		_, _ = fmt.Fprintf(stdErr, "=> %d %s [%s] took %s\n", statusCatcher.status, r.Method, r.URL, time.Since(start))
-	})
-
-	mw.UseFunc(func(w http.ResponseWriter, r *http.Request, n http.HandlerFunc) {
-		// Disable HSTS because it is very annoying to use on localhost.
-		w.Header().Set("Strict-Transport-Security", "max-age=0;")
-		n(w, r)
-	})
-
-	if !conf.noJWT {
-		mw.UseFunc(sessionToJWTMiddleware(conf, writer, key, signer, oryURL)) // This must be the last method before the handler
+	state := &proxyState{
+		stdErr:  stdErr,
+		writer:  herodot.NewJSONWriter(&errorLogger{Writer: stdErr}),
+		oryURL:  oryURL,
+		apiKey:  apiKey,
+		replay:  replay,
+		signer:  signer,
+		keys:    keys,
+		log:     newExchangeLog(conf),
+		metrics: newProxyMetrics(conf),
 	}
-
-	var upstream *url.URL
-	if conf.isTunnel {
-		upstream = oryURL
-	} else {
-		upstream, err = url.ParseRequestURI(conf.upstream)
-		if err != nil {
-			return errors.Wrap(err, "unable to parse upstream URL")
+	state.rateLimitName, state.rateLimitValue, _ = client.RateLimitHeader()
+	if conf.record != "" {
+		state.record = newRecorder(conf.record, stdErr)
+	}
+	if conf.mockSession != "" {
+		if state.mock, err = loadMockSessions(conf.mockSession); err != nil {
+			return err
 		}
 	}
 
-	mw.UseHandler(proxy.New(
-		func(ctx context.Context, r *http.Request) (context.Context, *proxy.HostConfig, error) {
-			if conf.isTunnel || strings.HasPrefix(r.URL.Path, conf.pathPrefix) {
-				return ctx, &proxy.HostConfig{
-					CookieDomain:   conf.cookieDomain,
-					UpstreamHost:   oryURL.Host,
-					UpstreamScheme: oryURL.Scheme,
-					TargetHost:     oryURL.Host,
-					PathPrefix:     conf.pathPrefix,
-				}, nil
-			}
-
-			return ctx, &proxy.HostConfig{
-				CookieDomain:   conf.cookieDomain,
-				UpstreamHost:   upstream.Host,
-				UpstreamScheme: upstream.Scheme,
-				TargetHost:     upstream.Host,
-				PathPrefix:     "",
-			}, nil
-		},
-		proxy.WithReqMiddleware(reqMiddleware(conf, oryURL, apiKey, rateLimitName, rateLimitValue)),
-		proxy.WithRespMiddleware(respMiddleware(conf)),
-	))
+	handler, err := newProxyHandler(conf, state)
+	if err != nil {
+		return err
+	}
+	live := new(liveHandler)
+	live.set(handler)
 
 	cleanup := func() error {
 		return nil
 	}
 
-	corsOpts, err := corsOptions(conf)
-	if err != nil {
-		return err
+	if conf.configFile != "" {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		if err := watchConfigFile(ctx, conf, stdErr, live, func(conf *config) (http.Handler, error) {
+			return newProxyHandler(conf, state)
+		}); err != nil {
+			return err
+		}
 	}
-	addr := fmt.Sprintf(":%d", conf.port)
-	ch := cors.New(corsOpts)
 
+	addr := fmt.Sprintf(":%d", conf.port)
 	server := graceful.WithDefaults(&http.Server{
 		Addr:    addr,
-		Handler: ch.Handler(mw),
+		Handler: withoutStreamDeadlines(live),
 		// graceful.WithDefaults would otherwise apply a 5s read and 10s write
 		// timeout, which cut slower exchanges off mid-flight and leave the client
 		// with an empty reply. The upstream here is the developer's own
-		// application and may legitimately take longer than that.
+		// application and may legitimately take longer than that. Streams are
+		// not limited at all.
 		ReadTimeout:  120 * time.Second,
 		WriteTimeout: 120 * time.Second,
 	})
+	if cert != nil {
+		server.TLSConfig.Certificates = []tls.Certificate{*cert}
+	}
 
 	if conf.isTunnel {
 		_, _ = fmt.Fprintf(stdErr, `To access Ory's APIs, use URL
@@ -251,6 +325,15 @@ and configure your SDKs to point to it, for example in JavaScript:
 `, conf.publicURL.String())
 	}
 
+	if conf.tls && conf.tlsCert == "" {
+		_, _ = fmt.Fprintf(stdErr, `The HTTPS certificate is issued by a local certificate authority. To make your browser
+trust it, install this certificate as a trusted root certificate:
+
+	%s
+
+`, filepath.Join(caDir(h), caCertFile))
+	}
+
 	if conf.open {
 		if err := browser.OpenURL(conf.publicURL.String()); err != nil {
 			_, _ = fmt.Fprintln(stdErr, "Unable to automatically open the proxy URL in your browser. Please open it manually!")
@@ -258,6 +341,9 @@ and configure your SDKs to point to it, for example in JavaScript:
 	}
 
 	if err := graceful.Graceful(func() error {
+		if cert != nil {
+			return server.ListenAndServeTLS("", "")
+		}
 		return server.ListenAndServe()
 	}, func(ctx context.Context) error {
 		_, _ = fmt.Fprintln(stdErr, "http server was shutdown gracefully")
@@ -273,6 +359,131 @@ and configure your SDKs to point to it, for example in JavaScript:
 	return nil
 }
 
+// proxyState is what the proxy keeps when its configuration is reloaded.
+type proxyState struct {
+	stdErr                        io.Writer
+	writer                        herodot.Writer
+	oryURL                        *url.URL
+	apiKey                        string
+	rateLimitName, rateLimitValue string
+	signer                        jose.Signer
+	keys                          *jose.JSONWebKeySet
+
+	replay  *replayer
+	record  *recorder
+	mock    *mockSessions
+	log     *exchangeLog
+	metrics *proxyMetrics
+}
+
+// newProxyHandler returns the handler serving all requests, built from the
+// configuration.
+func newProxyHandler(conf *config, state *proxyState) (http.Handler, error) {
+	mw := negroni.New()
+
+	mw.UseFunc(healthMiddleware(conf, state))
+
+	accessLog, err := accessLogMiddleware(conf, state.log, state.stdErr)
+	if err != nil {
+		return nil, err
+	}
+	mw.UseFunc(accessLog)
+	mw.UseFunc(state.metrics.middleware)
+
+	mw.UseFunc(func(w http.ResponseWriter, r *http.Request, n http.HandlerFunc) {
+		// Disable HSTS because it is very annoying to use on localhost.
+		w.Header().Set("Strict-Transport-Security", "max-age=0;")
+		n(w, r)
+	})
+
+	var routes []route
+	if !conf.isTunnel {
+		if routes, err = loadRoutes(conf); err != nil {
+			return nil, err
+		}
+	}
+
+	mapClaims, err := newClaimsMapper(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if !conf.noJWT || state.mock != nil || slices.ContainsFunc(routes, func(r route) bool { return r.jwt(conf) }) {
+		var debug io.Writer
+		if conf.isDebug {
+			debug = state.stdErr
+		}
+		cache := newSessionCache(conf.sessionCacheTTL, debug)
+		mw.UseFunc(sessionToJWTMiddleware(conf, routes, state.writer, state.keys, state.signer, mapClaims, cache, state.mock, state.metrics, state.oryURL)) // This must be the last method before the handler
+	}
+
+	if state.replay != nil {
+		mw.UseHandler(state.replay)
+	} else {
+		if state.record != nil {
+			mw.UseFunc(state.record.middleware)
+		}
+		buffered := proxy.New(
+			func(ctx context.Context, r *http.Request) (context.Context, *proxy.HostConfig, error) {
+				ex := exchangeFromContext(r.Context())
+				if conf.isTunnel || strings.HasPrefix(r.URL.Path, conf.pathPrefix) {
+					if ex != nil {
+						ex.Target, ex.Upstream = targetOry, state.oryURL.Host
+					}
+					return ctx, &proxy.HostConfig{
+						CookieDomain:   conf.cookieDomain,
+						UpstreamHost:   state.oryURL.Host,
+						UpstreamScheme: state.oryURL.Scheme,
+						TargetHost:     state.oryURL.Host,
+						PathPrefix:     conf.pathPrefix,
+					}, nil
+				}
+
+				target := matchRoute(routes, r)
+				if target == nil {
+					return ctx, nil, errors.Errorf("no route matches %s%s", r.Host, r.URL.Path)
+				}
+				if ex != nil {
+					ex.Target, ex.Upstream = targetUpstream, target.upstream.Host
+				}
+				var pathPrefix string
+				if target.StripPrefix && target.Path != "/" {
+					pathPrefix = target.Path
+				}
+				return ctx, &proxy.HostConfig{
+					CookieDomain:   conf.cookieDomain,
+					UpstreamHost:   target.upstream.Host,
+					UpstreamScheme: target.upstream.Scheme,
+					TargetHost:     target.upstream.Host,
+					PathPrefix:     pathPrefix,
+				}, nil
+			},
+			proxy.WithReqMiddleware(reqMiddleware(conf, state.oryURL, state.apiKey, state.rateLimitName, state.rateLimitValue)),
+			proxy.WithRespMiddleware(respMiddleware(conf)),
+		)
+		streams := newStreamProxy(conf, routes, state)
+		mw.UseHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The proxy reads whole bodies, which never end for streams.
+			if !conf.isTunnel && !strings.HasPrefix(r.URL.Path, conf.pathPrefix) && isStream(r) {
+				if target := matchRoute(routes, r); target != nil {
+					if ex := exchangeFromContext(r.Context()); ex != nil {
+						ex.Target, ex.Upstream = targetUpstream, target.upstream.Host
+					}
+					streams.ServeHTTP(w, r)
+					return
+				}
+			}
+			buffered.ServeHTTP(w, r)
+		}))
+	}
+
+	corsOpts, err := corsOptions(conf)
+	if err != nil {
+		return nil, err
+	}
+	return cors.New(corsOpts).Handler(mw), nil
+}
+
 // reqMiddleware returns the request middleware used by the reverse proxy. The
 // Ory-* headers (including the temporary API key in Ory-Base-URL-Rewrite-Token
 // and the rate-limit exemption header) are only attached to Ory-bound requests.
@@ -397,20 +608,16 @@ func respMiddleware(conf *config) proxy.RespMiddleware {
 	}
 }
 
-func newJWTSigner() (jose.Signer, *jose.JSONWebKeySet, error) {
-	key, err := jwksx.GenerateSigningKeys(
-		uuid.Must(uuid.NewV4()).String(),
-		"ES256",
-		0,
-	)
+func newJWTSigner(conf *config) (jose.Signer, *jose.JSONWebKeySet, error) {
+	keys, key, err := jwtSigningKeys(conf)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "unable to generate JSON Web Key")
+		return nil, nil, err
 	}
-	sig, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key.Keys[0].Key}, (&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", key.Keys[0].KeyID))
+	sig, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.SignatureAlgorithm(key.Algorithm), Key: key.Key}, (&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", key.KeyID))
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "unable to create signer")
 	}
-	return sig, key, nil
+	return sig, keys, nil
 }
 
 type errorLogger struct {
@@ -421,7 +628,7 @@ func (e *errorLogger) ReportError(r *http.Request, _ int, err error, _ ...any) {
 	_, _ = fmt.Fprintf(e.Writer, "encountered error on %s: %s\n", r.URL, err)
 }
 
-func sessionToJWTMiddleware(conf *config, writer herodot.Writer, keys *jose.JSONWebKeySet, sig jose.Signer, endpoint *url.URL) func(http.ResponseWriter, *http.Request, http.HandlerFunc) {
+func sessionToJWTMiddleware(conf *config, routes []route, writer herodot.Writer, keys *jose.JSONWebKeySet, sig jose.Signer, mapClaims claimsMapper, cache *sessionCache, mock *mockSessions, metrics *proxyMetrics, endpoint *url.URL) func(http.ResponseWriter, *http.Request, http.HandlerFunc) {
 	hc := httpx.NewResilientClient(httpx.ResilientClientWithMaxRetry(5), httpx.ResilientClientWithMaxRetryWait(time.Millisecond*5), httpx.ResilientClientWithConnectionTimeout(time.Second*30))
 
 	var publicKeys jose.JSONWebKeySet
@@ -435,33 +642,84 @@ func sessionToJWTMiddleware(conf *config, writer herodot.Writer, keys *jose.JSON
 			writer.Write(w, r, publicKeys)
 			return
 		}
+		if mock != nil {
+			switch r.URL.Path {
+			case conf.proxyPath(mockSessionPath):
+				mock.serveSwitcher(w, r, writer)
+				return
+			case path.Join(conf.pathPrefix, "/sessions/whoami"):
+				session := mock.session()
+				if !gjson.GetBytes(session, "active").Bool() {
+					writer.WriteError(w, r, errors.WithStack(herodot.ErrUnauthorized().WithReason("No valid session credentials found in the request.")))
+					return
+				}
+				writer.Write(w, r, session)
+				return
+			}
+		}
 
-		session, err := checkSession(hc, r, endpoint)
-		if err != nil || !gjson.GetBytes(session, "active").Bool() {
+		if len(conf.pathPrefix) > 0 && strings.HasPrefix(r.URL.Path, conf.pathPrefix) {
+			if changesSession(conf, r) {
+				cache.invalidate()
+			}
+			next(w, r)
+			return
+		}
+		if target := matchRoute(routes, r); target == nil || !target.jwt(conf) {
 			next(w, r)
 			return
 		}
 
-		if conf.noJWT || (len(conf.pathPrefix) > 0 && strings.HasPrefix(r.URL.Path, conf.pathPrefix)) {
+		session, ok := cache.get(r)
+		if mock != nil {
+			session, ok = mock.session(), true
+		}
+		if !ok {
+			var err error
+			start := time.Now()
+			session, err = checkSession(hc, r, endpoint)
+			metrics.observeSessionLookup(start, err)
+			if err != nil {
+				next(w, r)
+				return
+			}
+			cache.set(r, session)
+		}
+		active := gjson.GetBytes(session, "active").Bool()
+		ex := exchangeFromContext(r.Context())
+		if ex != nil {
+			ex.Session = &active
+		}
+		if !active {
 			next(w, r)
 			return
 		}
 
+		claims, err := mapClaims(session)
+		if err != nil {
+			writer.WriteError(w, r, err)
+			return
+		}
+
 		now := time.Now().UTC()
 		raw, err := jwt.Signed(sig).Claims(&jwt.Claims{
 			Issuer:    endpoint.String(),
 			Subject:   gjson.GetBytes(session, "identity.id").String(),
-			Expiry:    jwt.NewNumericDate(now.Add(time.Minute)),
+			Audience:  conf.jwtAudience,
+			Expiry:    jwt.NewNumericDate(now.Add(conf.jwtTTL)),
 			NotBefore: jwt.NewNumericDate(now),
 			IssuedAt:  jwt.NewNumericDate(now),
 			ID:        uuid.Must(uuid.NewV4()).String(),
-		}).Claims(map[string]interface{}{"session": session}).CompactSerialize()
+		}).Claims(claims).CompactSerialize()
 		if err != nil {
 			writer.WriteError(w, r, err)
 			return
 		}
 
 		r.Header.Set("Authorization", "Bearer "+raw)
+		if ex != nil {
+			ex.JWT = true
+		}
 		next(w, r)
 	}
 }
@@ -486,6 +744,12 @@ func checkSession(c *retryablehttp.Client, r *http.Request, target *url.URL) (js
 	}
 	defer res.Body.Close()
 
+	// Anything but a session or the lack of one, for example being rate
+	// limited, must not be mistaken for the result of the lookup.
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusUnauthorized {
+		return nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to check the session: the session checker responded with status %d", res.StatusCode))
+	}
+
 	var body json.RawMessage
 	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
 		return nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to decode session to JSON: %s", err).WithWrap(err))
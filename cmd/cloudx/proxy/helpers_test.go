@@ -101,7 +101,7 @@ func TestCORSHeadersAreNotDuplicated(t *testing.T) {
 		return err
 	}
 
-	// Built from the same helper runReverseProxy uses, so the test cannot drift
+	// Built from the same helper newProxyHandler uses, so the test cannot drift
 	// away from the CORS configuration the proxy actually runs.
 	corsOpts, err := corsOptions(conf)
 	require.NoError(t, err)
@@ -0,0 +1,198 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"cmp"
+	"encoding/json"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/google/go-jsonnet"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	"github.com/ory/x/jwksx"
+)
+
+const (
+	JWTJWKSFlag      = "jwt-jwks"
+	JWTAlgorithmFlag = "jwt-algorithm"
+	JWTTTLFlag       = "jwt-ttl"
+	JWTAudienceFlag  = "jwt-audience"
+	JWTClaimsFlag    = "jwt-claims"
+	JWTClaimFlag     = "jwt-claim"
+)
+
+const defaultJWTAlgorithm = jose.ES256
+
+// jwtAlgorithms are the algorithms the JWT can be signed with. Symmetric
+// algorithms are left out, as the upstream verifies the JWT using the public
+// keys.
+var jwtAlgorithms = []string{
+	string(jose.ES256), string(jose.ES384), string(jose.ES512),
+	string(jose.RS256), string(jose.RS384), string(jose.RS512),
+	string(jose.PS256), string(jose.PS384), string(jose.PS512),
+	string(jose.EdDSA),
+}
+
+func registerJWTFlags(conf *config, flags *pflag.FlagSet) {
+	flags.StringVar(&conf.jwtJWKS, JWTJWKSFlag, "", "Sign the JWT with the first private key of this JSON Web Key Set. If the file does not exist, it is created with a new key, so the key stays the same across restarts.")
+	flags.StringVar(&conf.jwtAlgorithm, JWTAlgorithmFlag, "", "The algorithm to sign the JWT with, one of "+strings.Join(jwtAlgorithms, ", ")+". Defaults to the algorithm of the key set with --"+JWTJWKSFlag+", or "+string(defaultJWTAlgorithm)+".")
+	flags.DurationVar(&conf.jwtTTL, JWTTTLFlag, time.Minute, "How long the JWT is valid.")
+	flags.StringSliceVar(&conf.jwtAudience, JWTAudienceFlag, nil, "The audience(s) of the JWT.")
+	flags.StringVar(&conf.jwtClaims, JWTClaimsFlag, "", "A Jsonnet file returning the JWT's claims from the Ory Session, available as std.extVar('session'). The claims it returns take precedence over the standard claims.")
+	flags.StringArrayVar(&conf.jwtClaimPointers, JWTClaimFlag, nil, "Set a claim of the JWT to the value at a JSON pointer of the Ory Session, as `name=/json/pointer`. Can be repeated.")
+}
+
+// jwtSigningKeys returns the keys to sign the JWT with, and the one to use.
+// Unless a JSON Web Key Set file is configured, a new key is generated on
+// every start.
+func jwtSigningKeys(conf *config) (*jose.JSONWebKeySet, *jose.JSONWebKey, error) {
+	if conf.jwtAlgorithm != "" && !slices.Contains(jwtAlgorithms, conf.jwtAlgorithm) {
+		return nil, nil, errors.Errorf("the JWT algorithm %q is not supported, use one of %s", conf.jwtAlgorithm, strings.Join(jwtAlgorithms, ", "))
+	}
+	alg := cmp.Or(conf.jwtAlgorithm, string(defaultJWTAlgorithm))
+
+	if conf.jwtJWKS == "" {
+		keys, err := jwksx.GenerateSigningKeys("", alg, 0)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "unable to generate JSON Web Key")
+		}
+		return keys, &keys.Keys[0], nil
+	}
+
+	raw, err := os.ReadFile(conf.jwtJWKS)
+	if os.IsNotExist(err) {
+		keys, err := jwksx.GenerateSigningKeys("", alg, 0)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "unable to generate JSON Web Key")
+		}
+		raw, err := json.MarshalIndent(keys, "", "  ")
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		if err := os.WriteFile(conf.jwtJWKS, raw, 0600); err != nil {
+			return nil, nil, errors.Wrap(err, "unable to write the JSON Web Key Set")
+		}
+		return keys, &keys.Keys[0], nil
+	} else if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to read the JSON Web Key Set")
+	}
+
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		return nil, nil, errors.Wrapf(err, "unable to decode the JSON Web Key Set %s", conf.jwtJWKS)
+	}
+	for k := range keys.Keys {
+		key := &keys.Keys[k]
+		if key.IsPublic() || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		if key.Algorithm == "" {
+			key.Algorithm = alg
+		} else if conf.jwtAlgorithm != "" && key.Algorithm != conf.jwtAlgorithm {
+			return nil, nil, errors.Errorf("the key %q in %s is for algorithm %s, not %s", key.KeyID, conf.jwtJWKS, key.Algorithm, conf.jwtAlgorithm)
+		}
+		if !slices.Contains(jwtAlgorithms, key.Algorithm) {
+			return nil, nil, errors.Errorf("the algorithm %s of the key %q in %s is not supported, use one of %s", key.Algorithm, key.KeyID, conf.jwtJWKS, strings.Join(jwtAlgorithms, ", "))
+		}
+		return &keys, key, nil
+	}
+	return nil, nil, errors.Errorf("the JSON Web Key Set %s contains no private signing key", conf.jwtJWKS)
+}
+
+// claimsMapper returns the claims of the JWT for a session.
+type claimsMapper func(session json.RawMessage) (map[string]any, error)
+
+// newClaimsMapper returns the claims mapper configured using --jwt-claims or
+// --jwt-claim. Without either, the whole session is the `session` claim.
+func newClaimsMapper(conf *config) (claimsMapper, error) {
+	switch {
+	case conf.jwtClaims != "" && len(conf.jwtClaimPointers) > 0:
+		return nil, errors.Errorf("--%s and --%s can not be used together", JWTClaimsFlag, JWTClaimFlag)
+
+	case conf.jwtClaims != "":
+		snippet, err := os.ReadFile(conf.jwtClaims)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read the JWT claims mapper")
+		}
+		return func(session json.RawMessage) (map[string]any, error) {
+			vm := jsonnet.MakeVM()
+			vm.ExtCode("session", string(session))
+			out, err := vm.EvaluateAnonymousSnippet(conf.jwtClaims, string(snippet))
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to evaluate the JWT claims mapper")
+			}
+			var claims map[string]any
+			if err := json.Unmarshal([]byte(out), &claims); err != nil {
+				return nil, errors.Wrap(err, "the JWT claims mapper must return an object")
+			}
+			return claims, nil
+		}, nil
+
+	case len(conf.jwtClaimPointers) > 0:
+		pointers := make(map[string][]string, len(conf.jwtClaimPointers))
+		for _, v := range conf.jwtClaimPointers {
+			name, pointer, ok := strings.Cut(v, "=")
+			if !ok || name == "" || (pointer != "" && !strings.HasPrefix(pointer, "/")) {
+				return nil, errors.Errorf("the JWT claim %q must have the format name=/json/pointer", v)
+			}
+			pointers[name] = splitJSONPointer(pointer)
+		}
+		return func(session json.RawMessage) (map[string]any, error) {
+			var doc any
+			if err := json.Unmarshal(session, &doc); err != nil {
+				return nil, errors.WithStack(err)
+			}
+			claims := make(map[string]any, len(pointers))
+			for name, segments := range pointers {
+				if v, ok := lookupJSONPointer(doc, segments); ok {
+					claims[name] = v
+				}
+			}
+			return claims, nil
+		}, nil
+	}
+
+	return func(session json.RawMessage) (map[string]any, error) {
+		return map[string]any{"session": session}, nil
+	}, nil
+}
+
+func splitJSONPointer(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	segments := strings.Split(pointer[1:], "/")
+	for k, s := range segments {
+		segments[k] = strings.NewReplacer("~1", "/", "~0", "~").Replace(s)
+	}
+	return segments
+}
+
+func lookupJSONPointer(doc any, segments []string) (any, bool) {
+	for _, s := range segments {
+		switch v := doc.(type) {
+		case map[string]any:
+			var ok bool
+			if doc, ok = v[s]; !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(s)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			doc = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return doc, true
+}
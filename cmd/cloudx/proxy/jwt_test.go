@@ -0,0 +1,161 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/herodot"
+)
+
+func TestJWTSigningKeys(t *testing.T) {
+	t.Run("case=persistent key set", func(t *testing.T) {
+		for _, alg := range []string{"RS256", "EdDSA"} {
+			t.Run("alg="+alg, func(t *testing.T) {
+				conf := &config{jwtJWKS: filepath.Join(t.TempDir(), "jwks.json"), jwtAlgorithm: alg}
+				keys, key, err := jwtSigningKeys(conf)
+				require.NoError(t, err)
+				assert.Equal(t, alg, key.Algorithm)
+
+				fi, err := os.Stat(conf.jwtJWKS)
+				require.NoError(t, err)
+				assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+				reloaded, key2, err := jwtSigningKeys(&config{jwtJWKS: conf.jwtJWKS})
+				require.NoError(t, err)
+				assert.Equal(t, key.KeyID, key2.KeyID, "the key must stay the same across restarts")
+				assert.Equal(t, alg, key2.Algorithm)
+				assert.Len(t, reloaded.Keys, len(keys.Keys))
+
+				_, _, err = jwtSigningKeys(&config{jwtJWKS: conf.jwtJWKS, jwtAlgorithm: "ES256"})
+				assert.ErrorContains(t, err, "is for algorithm "+alg)
+			})
+		}
+	})
+
+	t.Run("case=only public keys", func(t *testing.T) {
+		keys, key, err := jwtSigningKeys(&config{})
+		require.NoError(t, err)
+		assert.Equal(t, "ES256", key.Algorithm)
+
+		path := filepath.Join(t.TempDir(), "jwks.json")
+		raw, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{keys.Keys[0].Public()}})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, raw, 0600))
+
+		_, _, err = jwtSigningKeys(&config{jwtJWKS: path})
+		assert.ErrorContains(t, err, "contains no private signing key")
+	})
+
+	t.Run("case=unsupported algorithm", func(t *testing.T) {
+		_, _, err := jwtSigningKeys(&config{jwtAlgorithm: "HS256"})
+		assert.ErrorContains(t, err, "is not supported")
+	})
+}
+
+func TestClaimsMapper(t *testing.T) {
+	session := json.RawMessage(`{
+  "id": "821f5a53-a0b3-41fa-9c62-764560fa4406",
+  "active": true,
+  "identity": {
+    "id": "18aafd3e-b00c-4b19-81c8-351e38705126",
+    "traits": {"email": "foo@bar", "tags": ["a", "b"]}
+  }
+}`)
+
+	t.Run("case=session", func(t *testing.T) {
+		mapClaims, err := newClaimsMapper(&config{})
+		require.NoError(t, err)
+		claims, err := mapClaims(session)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"session": session}, claims)
+	})
+
+	t.Run("case=json pointers", func(t *testing.T) {
+		mapClaims, err := newClaimsMapper(&config{jwtClaimPointers: []string{
+			"email=/identity/traits/email",
+			"tag=/identity/traits/tags/1",
+			"sid=/id",
+			"missing=/identity/traits/name",
+		}})
+		require.NoError(t, err)
+		claims, err := mapClaims(session)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"email": "foo@bar", "tag": "b", "sid": "821f5a53-a0b3-41fa-9c62-764560fa4406"}, claims)
+
+		_, err = newClaimsMapper(&config{jwtClaimPointers: []string{"email"}})
+		assert.ErrorContains(t, err, "name=/json/pointer")
+	})
+
+	t.Run("case=jsonnet", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "claims.jsonnet")
+		require.NoError(t, os.WriteFile(path, []byte(`local session = std.extVar('session');
+{
+  email: session.identity.traits.email,
+  tags: std.length(session.identity.traits.tags),
+}`), 0600))
+
+		mapClaims, err := newClaimsMapper(&config{jwtClaims: path})
+		require.NoError(t, err)
+		claims, err := mapClaims(session)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"email": "foo@bar", "tags": float64(2)}, claims)
+
+		_, err = newClaimsMapper(&config{jwtClaims: path, jwtClaimPointers: []string{"sid=/id"}})
+		assert.ErrorContains(t, err, "can not be used together")
+	})
+}
+
+func TestSessionToJWTMiddleware(t *testing.T) {
+	ory := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "821f5a53-a0b3-41fa-9c62-764560fa4406", "active": true, "identity": {"id": "18aafd3e-b00c-4b19-81c8-351e38705126", "traits": {"email": "foo@bar"}}}`))
+	}))
+	t.Cleanup(ory.Close)
+	endpoint, err := url.Parse(ory.URL)
+	require.NoError(t, err)
+
+	conf := &config{
+		jwtAlgorithm:     "EdDSA",
+		jwtTTL:           5 * time.Minute,
+		jwtAudience:      []string{"https://api.example.org"},
+		jwtClaimPointers: []string{"email=/identity/traits/email"},
+	}
+	sig, keys, err := newJWTSigner(conf)
+	require.NoError(t, err)
+	mapClaims, err := newClaimsMapper(conf)
+	require.NoError(t, err)
+	routes, err := loadRoutes(&config{upstream: "http://localhost:3000"})
+	require.NoError(t, err)
+
+	var token string
+	mw := sessionToJWTMiddleware(conf, routes, herodot.NewJSONWriter(nil), keys, sig, mapClaims, newSessionCache(0, nil), nil, nil, endpoint)
+	mw(httptest.NewRecorder(), httptest.NewRequest("GET", "http://localhost:4000/", nil), func(_ http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("Authorization")
+	})
+	require.NotEmpty(t, token)
+
+	parsed, err := jwt.ParseSigned(token[len("Bearer "):])
+	require.NoError(t, err)
+	assert.Equal(t, "EdDSA", parsed.Headers[0].Algorithm)
+
+	var claims jwt.Claims
+	var custom map[string]any
+	require.NoError(t, parsed.Claims(keys.Keys[0].Public().Key, &claims, &custom))
+	require.NoError(t, claims.ValidateWithLeeway(jwt.Expected{Audience: jwt.Audience{"https://api.example.org"}, Time: time.Now().Add(4 * time.Minute)}, 0))
+	assert.Equal(t, "18aafd3e-b00c-4b19-81c8-351e38705126", claims.Subject)
+	assert.Equal(t, "foo@bar", custom["email"])
+	assert.NotContains(t, custom, "session")
+}
@@ -0,0 +1,94 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/pflag"
+)
+
+const MetricsFlag = "metrics"
+
+const metricsPath = "/proxy/metrics"
+
+func registerMetricsFlags(conf *config, flags *pflag.FlagSet) {
+	flags.BoolVar(&conf.metrics, MetricsFlag, false, "Serve Prometheus metrics at /.ory"+metricsPath+".")
+}
+
+// proxyMetrics are the Prometheus metrics of the proxy. A nil *proxyMetrics
+// records nothing.
+type proxyMetrics struct {
+	registry            *prometheus.Registry
+	requests            *prometheus.CounterVec
+	latency             *prometheus.HistogramVec
+	sessionLookups      prometheus.Histogram
+	sessionLookupErrors prometheus.Counter
+}
+
+func newProxyMetrics(conf *config) *proxyMetrics {
+	if !conf.metrics {
+		return nil
+	}
+	m := &proxyMetrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ory_proxy_requests_total",
+			Help: "The number of requests, by target, method, and status code.",
+		}, []string{"target", "method", "code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "ory_proxy_request_duration_seconds",
+			Help:    "The time until the response to a request is complete, by target.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"target"}),
+		sessionLookups: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "ory_proxy_session_lookup_duration_seconds",
+			Help:    "The time it takes to look up the Ory Session of a request at /sessions/whoami.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		sessionLookupErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "ory_proxy_session_lookup_errors_total",
+			Help: "The number of failed Ory Session lookups.",
+		}),
+	}
+	m.registry.MustRegister(m.requests, m.latency, m.sessionLookups, m.sessionLookupErrors)
+	return m
+}
+
+// middleware counts the requests by the target set on their exchange.
+func (m *proxyMetrics) middleware(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if m == nil {
+		next(w, r)
+		return
+	}
+
+	start := time.Now()
+	statusCatcher := &responseStatusCatcher{ResponseWriter: w}
+	next(statusCatcher, r)
+
+	target := "none"
+	if ex := exchangeFromContext(r.Context()); ex != nil && ex.Target != "" {
+		target = ex.Target
+	}
+	m.requests.WithLabelValues(target, r.Method, strconv.Itoa(responseStatus(statusCatcher.status))).Inc()
+	m.latency.WithLabelValues(target).Observe(time.Since(start).Seconds())
+}
+
+func (m *proxyMetrics) observeSessionLookup(start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	m.sessionLookups.Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.sessionLookupErrors.Inc()
+	}
+}
+
+func (m *proxyMetrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,230 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/herodot"
+)
+
+const MockSessionFlag = "mock-session"
+
+const mockSessionPath = "/proxy/mock-session"
+
+func registerMockSessionFlags(conf *config, flags *pflag.FlagSet) {
+	flags.StringVar(&conf.mockSession, MockSessionFlag, "", "Do not look up the Ory Session, but use a session of the identity in this JSON file instead. If the file contains a list of identities, switch between them at "+path.Join("/.ory", mockSessionPath)+".")
+}
+
+// mockSessions are the synthetic sessions of the identities passed to
+// --mock-session, of which one or none is active.
+type mockSessions struct {
+	now func() time.Time
+
+	mu       sync.Mutex
+	sessions []json.RawMessage
+	current  int
+}
+
+// loadMockSessions reads an identity, or a list of identities, and creates a
+// session for each of them. The first identity is signed in initially.
+func loadMockSessions(file string) (*mockSessions, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the mock identities")
+	}
+
+	var identities []json.RawMessage
+	raw = bytes.TrimSpace(raw)
+	if bytes.HasPrefix(raw, []byte("[")) {
+		err = json.Unmarshal(raw, &identities)
+	} else {
+		identities = make([]json.RawMessage, 1)
+		err = json.Unmarshal(raw, &identities[0])
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to decode the mock identities in %s", file)
+	}
+	if len(identities) == 0 {
+		return nil, errors.Errorf("%s contains no identity", file)
+	}
+
+	m := &mockSessions{now: time.Now}
+	seen := make(map[string]bool, len(identities))
+	for k, identity := range identities {
+		if !gjson.ParseBytes(identity).IsObject() {
+			return nil, errors.Errorf("identity %d in %s is not a JSON object", k, file)
+		}
+		identity, err := withIdentityDefaults(identity)
+		if err != nil {
+			return nil, err
+		}
+		id := gjson.GetBytes(identity, "id").String()
+		if seen[id] {
+			return nil, errors.Errorf("the identity ID %s is used more than once in %s", id, file)
+		}
+		seen[id] = true
+		m.sessions = append(m.sessions, identity)
+	}
+	return m, nil
+}
+
+// withIdentityDefaults fills in what Ory would have set on the identity.
+func withIdentityDefaults(identity json.RawMessage) (json.RawMessage, error) {
+	defaults := map[string]any{
+		"id":        uuid.Must(uuid.NewV4()).String(),
+		"schema_id": "preset://email",
+		"state":     "active",
+		"traits":    map[string]any{},
+	}
+	raw := []byte(identity)
+	for key, value := range defaults {
+		if gjson.GetBytes(raw, key).Exists() {
+			continue
+		}
+		var err error
+		if raw, err = sjson.SetBytes(raw, key, value); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return raw, nil
+}
+
+// session returns the session of the signed in identity, or the response of
+// Ory if no identity is signed in.
+func (m *mockSessions) session() json.RawMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.current < 0 {
+		return json.RawMessage(`{"error": {"code": 401, "status": "Unauthorized", "message": "No valid session credentials found in the request."}}`)
+	}
+
+	identity := m.sessions[m.current]
+	now := m.now().UTC()
+	session, _ := json.Marshal(map[string]any{
+		// The session ID is derived from the identity, so that it stays the
+		// same while the identity is signed in.
+		"id":                            uuid.NewV5(uuid.NamespaceOID, gjson.GetBytes(identity, "id").String()).String(),
+		"active":                        true,
+		"expires_at":                    now.Add(24 * time.Hour),
+		"authenticated_at":              now,
+		"issued_at":                     now,
+		"authenticator_assurance_level": "aal1",
+		"authentication_methods":        []map[string]any{{"method": "password", "aal": "aal1", "completed_at": now}},
+		"identity":                      identity,
+	})
+	return session
+}
+
+// signIn switches to the identity with the ID. An empty ID signs out.
+func (m *mockSessions) signIn(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if id == "" {
+		m.current = -1
+		return nil
+	}
+	for k, identity := range m.sessions {
+		if gjson.GetBytes(identity, "id").String() == id {
+			m.current = k
+			return nil
+		}
+	}
+	return errors.WithStack(herodot.ErrNotFound().WithReasonf("There is no mock identity with ID %s.", id))
+}
+
+type mockIdentity struct {
+	ID       string          `json:"id"`
+	Traits   json.RawMessage `json:"traits"`
+	SignedIn bool            `json:"signed_in"`
+}
+
+func (m *mockSessions) identities() []mockIdentity {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	identities := make([]mockIdentity, len(m.sessions))
+	for k, identity := range m.sessions {
+		identities[k] = mockIdentity{
+			ID:       gjson.GetBytes(identity, "id").String(),
+			Traits:   json.RawMessage(gjson.GetBytes(identity, "traits").Raw),
+			SignedIn: k == m.current,
+		}
+	}
+	return identities
+}
+
+var mockSessionTemplate = template.Must(template.New("mock-session").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Ory Proxy Mock Sessions</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em; }
+form { border-bottom: 1px solid #ddd; padding: .5em 0; }
+code { font-size: .9em; }
+</style>
+</head>
+<body>
+<h1>Ory Proxy Mock Sessions</h1>
+{{range .}}
+<form method="post">
+<input type="hidden" name="identity" value="{{.ID}}">
+<button type="submit"{{if .SignedIn}} disabled{{end}}>{{if .SignedIn}}Signed in{{else}}Sign in{{end}}</button>
+<code>{{.ID}}</code> <code>{{printf "%s" .Traits}}</code>
+</form>
+{{end}}
+<form method="post">
+<input type="hidden" name="identity" value="">
+<button type="submit">Sign out</button>
+</form>
+</body>
+</html>
+`))
+
+// serveSwitcher lists the mock identities, and signs in the identity posted
+// as the identity form field.
+func (m *mockSessions) serveSwitcher(w http.ResponseWriter, r *http.Request, writer herodot.Writer) {
+	wantsJSON := r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json")
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if err := r.ParseForm(); err != nil {
+			writer.WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithWrap(err)))
+			return
+		}
+		if err := m.signIn(r.PostForm.Get("identity")); err != nil {
+			writer.WriteError(w, r, err)
+			return
+		}
+		if !wantsJSON {
+			http.Redirect(w, r, r.URL.Path, http.StatusSeeOther)
+			return
+		}
+	default:
+		writer.WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The method %s is not supported.", r.Method)))
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	if wantsJSON {
+		writer.Write(w, r, m.identities())
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = mockSessionTemplate.Execute(w, m.identities())
+}
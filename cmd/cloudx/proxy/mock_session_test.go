@@ -0,0 +1,125 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+)
+
+func TestMockSessions(t *testing.T) {
+	writeFile := func(t *testing.T, content string) string {
+		file := filepath.Join(t.TempDir(), "identity.json")
+		require.NoError(t, os.WriteFile(file, []byte(content), 0600))
+		return file
+	}
+
+	t.Run("case=single identity", func(t *testing.T) {
+		m, err := loadMockSessions(writeFile(t, `{"traits": {"email": "foo@bar"}}`))
+		require.NoError(t, err)
+		session := m.session()
+		assert.True(t, gjson.GetBytes(session, "active").Bool())
+		assert.Equal(t, "foo@bar", gjson.GetBytes(session, "identity.traits.email").String())
+		assert.NotEmpty(t, gjson.GetBytes(session, "identity.id").String(), "the identity ID is generated")
+		assert.Equal(t, "preset://email", gjson.GetBytes(session, "identity.schema_id").String())
+		assert.Equal(t, gjson.GetBytes(session, "id").String(), gjson.GetBytes(m.session(), "id").String(), "the session ID stays the same")
+	})
+
+	t.Run("case=invalid", func(t *testing.T) {
+		for content, msg := range map[string]string{
+			`[]`:                              "contains no identity",
+			`["foo"]`:                         "is not a JSON object",
+			`[{"id": "a"}, {"id": "a"}]`:      "is used more than once",
+			`{"traits": {"email": "foo@bar"}`: "unable to decode",
+		} {
+			_, err := loadMockSessions(writeFile(t, content))
+			assert.ErrorContains(t, err, msg, content)
+		}
+	})
+
+	t.Run("case=middleware", func(t *testing.T) {
+		m, err := loadMockSessions(writeFile(t, `[
+  {"id": "18aafd3e-b00c-4b19-81c8-351e38705126", "traits": {"email": "admin@bar"}},
+  {"id": "5a2f0b5c-0a5d-4c4e-9c4f-6b9a1f2b3c4d", "traits": {"email": "user@bar"}}
+]`))
+		require.NoError(t, err)
+
+		endpoint, err := url.Parse("http://ory.invalid")
+		require.NoError(t, err)
+		conf := &config{pathPrefix: "/.ory", jwtTTL: time.Minute}
+		sig, keys, err := newJWTSigner(conf)
+		require.NoError(t, err)
+		mapClaims, err := newClaimsMapper(conf)
+		require.NoError(t, err)
+		routes, err := loadRoutes(&config{upstream: "http://localhost:3000"})
+		require.NoError(t, err)
+		mw := sessionToJWTMiddleware(conf, routes, herodot.NewJSONWriter(nil), keys, sig, mapClaims, newSessionCache(0, nil), m, nil, endpoint)
+
+		serve := func(r *http.Request) (*httptest.ResponseRecorder, string) {
+			w := httptest.NewRecorder()
+			var authorization string
+			mw(w, r, func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+			})
+			return w, authorization
+		}
+		subject := func(t *testing.T) string {
+			_, authorization := serve(httptest.NewRequest("GET", "http://localhost:4000/", nil))
+			if authorization == "" {
+				return ""
+			}
+			parsed, err := jwt.ParseSigned(strings.TrimPrefix(authorization, "Bearer "))
+			require.NoError(t, err)
+			var claims jwt.Claims
+			require.NoError(t, parsed.Claims(keys.Keys[0].Public().Key, &claims))
+			return claims.Subject
+		}
+		switchTo := func(t *testing.T, id string) {
+			r := httptest.NewRequest("POST", "http://localhost:4000/.ory/proxy/mock-session", strings.NewReader(url.Values{"identity": {id}}.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w, _ := serve(r)
+			assert.Equal(t, http.StatusSeeOther, w.Code, w.Body.String())
+		}
+
+		w, _ := serve(httptest.NewRequest("GET", "http://localhost:4000/.ory/sessions/whoami", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "admin@bar", gjson.Get(w.Body.String(), "identity.traits.email").String())
+		assert.Equal(t, "18aafd3e-b00c-4b19-81c8-351e38705126", subject(t))
+
+		switchTo(t, "5a2f0b5c-0a5d-4c4e-9c4f-6b9a1f2b3c4d")
+		assert.Equal(t, "5a2f0b5c-0a5d-4c4e-9c4f-6b9a1f2b3c4d", subject(t))
+
+		r := httptest.NewRequest("GET", "http://localhost:4000/.ory/proxy/mock-session?format=json", nil)
+		w, _ = serve(r)
+		var identities []mockIdentity
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &identities))
+		require.Len(t, identities, 2)
+		assert.False(t, identities[0].SignedIn)
+		assert.True(t, identities[1].SignedIn)
+
+		switchTo(t, "")
+		assert.Empty(t, subject(t))
+		w, _ = serve(httptest.NewRequest("GET", "http://localhost:4000/.ory/sessions/whoami", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		r = httptest.NewRequest("POST", "http://localhost:4000/.ory/proxy/mock-session", strings.NewReader("identity=unknown"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w, _ = serve(r)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
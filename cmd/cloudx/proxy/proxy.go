@@ -21,7 +21,7 @@ func NewProxyCommand() *cobra.Command {
 	proxyCmd := &cobra.Command{
 		Use:   "proxy <application-url> [<publish-url>]",
 		Short: "Run your app and Ory on the same domain using a reverse proxy",
-		Args:  cobra.RangeArgs(1, 2),
+		Args:  argsUnlessPrintingCA(&conf, cobra.RangeArgs(1, 2)),
 		Example: `{{.CommandPath}} http://localhost:3000
 `,
 		Long: `The Ory Proxy allows your application and Ory to run on the same domain by acting as a reverse proxy. It forwards all traffic to your application, ensuring that features like cookies and CORS function correctly during local development.
@@ -84,6 +84,100 @@ If you are using the Ory Proxy behind a gateway during development, you must set
 
 Note: You cannot set a path in the ` + "`publish-url`" + `.
 
+### HTTPS
+
+Browser features such as WebAuthn and passkeys, ` + "`Secure`" + ` and ` + "`SameSite=None`" + ` cookies, and OAuth2 providers requiring HTTPS redirect URIs only work in a secure context. Use the ` + "`--tls`" + ` flag to serve HTTPS:
+
+		$ {{.CommandPath}} --tls --project <project-id-or-slug> http://localhost:3000
+
+The certificate is issued for ` + "`localhost`" + ` and the host of the ` + "`publish-url`" + ` by a local certificate authority, which the CLI creates on first use and stores next to its configuration file. Install the certificate authority as trusted once to make browsers accept the certificate. To print its path:
+
+		$ {{.CommandPath}} --tls-print-ca
+
+To use your own certificate instead, set ` + "`--tls-cert`" + ` and ` + "`--tls-key`" + `:
+
+		$ {{.CommandPath}} --tls-cert cert.pem --tls-key key.pem --project <project-id-or-slug> http://localhost:3000
+
+### Multiple upstreams
+
+To put several applications behind the proxy, for example a single-page app, an API, and an admin app running on different ports, forward requests to other upstreams than the ` + "`application-url`" + ` using the ` + "`--route`" + ` flag. All of them share the proxy's domain and therefore Ory's cookies:
+
+		$ {{.CommandPath}} --project <project-id-or-slug> http://localhost:3000 \
+			--route /api=http://localhost:8080 \
+			--route admin.localhost/=http://localhost:9000,no-jwt
+
+A route matches a path and everything below it, optionally only for a host (without port, ` + "`*.`" + ` matches all subdomains). Routes for a host take precedence, then the longest path wins. Requests no route matches are forwarded to the ` + "`application-url`" + `. The options ` + "`jwt=true|false`" + ` (or ` + "`no-jwt`" + `) configure whether the route's upstream receives the JSON Web Token, and ` + "`strip-prefix`" + ` removes the route's path before forwarding.
+
+Routes can also be defined in a YAML or JSON file using the ` + "`--routes-file`" + ` flag:
+
+		routes:
+		  - path: /api
+		    upstream: http://localhost:8080
+		    strip_prefix: true
+		  - host: admin.localhost
+		    upstream: http://localhost:9000
+		    jwt: false
+
+### WebSockets and Server-Sent Events
+
+WebSocket connections and other protocol upgrades, as well as Server-Sent Events requested with ` + "`Accept: text/event-stream`" + `, are forwarded to your application as streams: they are neither buffered nor rewritten, and they stay open for as long as your application keeps them open. The JWT is added to the request opening the stream, such as the WebSocket handshake.
+
+### Debugging
+
+Every request is logged to stderr. Use ` + "`--log-format json`" + ` for one JSON object per request, with the status, latency, whether the request was forwarded to Ory or the upstream, the ` + "`Location`" + ` header, the names of the cookies set, and whether a session was present and a JWT attached.
+
+To look at the headers of the last requests and responses, start the proxy with ` + "`--inspect`" + ` and open:
+
+		http://localhost:4000/.ory/proxy/inspect
+
+Cookies, tokens, and API keys are redacted. Append ` + "`?format=json`" + ` to get the requests as JSON.
+
+### Mock sessions
+
+To work on your user interface without signing in, let the proxy pretend that a user is signed in. Write the identity, with the traits your application needs, to a JSON file:
+
+		{
+		  "id": "18aafd3e-b00c-4b19-81c8-351e38705126",
+		  "traits": {"email": "foo@bar"}
+		}
+
+		$ {{.CommandPath}} --mock-session identity.json --project <project-id-or-slug> http://localhost:3000
+
+The proxy then answers ` + "`/.ory/sessions/whoami`" + ` with a session of this identity instead of asking Ory, and creates the JWT from it. Missing fields such as the identity's ID are filled in. If the file contains a list of identities, the first one is signed in, and you can switch to another one, or sign out, at:
+
+		http://localhost:4000/.ory/proxy/mock-session
+
+All other requests to Ory, for example to self-service flows, still reach your project.
+
+### Configuration file
+
+Instead of passing flags, you can write the settings to a YAML file whose keys are the names of the flags, and pass it with ` + "`--config-file`" + `:
+
+		port: 4000
+		cookie-domain: localhost
+		allowed-cors-origins:
+		  - https://www.example.org
+		additional-cors-headers:
+		  - X-Custom-Header
+		default-redirect-url: http://localhost:4000/welcome
+		rewrite-host: true
+		path-prefix: /.ory
+
+		$ {{.CommandPath}} --config-file proxy.yaml --project <project-id-or-slug> http://localhost:3000
+
+The file also sets the path under which Ory's APIs are served, ` + "`path-prefix`" + `, which defaults to ` + "`/.ory`" + `. Flags take precedence over the file. The proxy applies changes to the file while it is running, without creating a new API key. A change of the port, the HTTPS settings, the JWT signing key, the inspector, the metrics, or the mock session still requires a restart.
+
+### Health checks and metrics
+
+The proxy answers health checks, for example of Docker Compose or Kubernetes, at:
+
+		http://localhost:4000/.ory/proxy/health/alive
+		http://localhost:4000/.ory/proxy/health/ready
+
+The first one responds with 200 as soon as the proxy accepts requests. The second one additionally checks that your project answers and accepts the temporary API key, and responds with 503 otherwise.
+
+With ` + "`--metrics`" + `, the proxy serves Prometheus metrics at ` + "`/.ory/proxy/metrics`" + `: the number of requests and their latencies by target, and the latency and errors of looking up sessions.
+
 ### Ports
 
 By default, the proxy listens on port 4000. To change this, use the ` + "`--port`" + ` flag:
@@ -131,6 +225,30 @@ The JWT is signed using the ES256 algorithm. You can fetch the public key by que
 
 http://127.0.0.1:4000/.ory/jwks.json
 
+To verify the JWT against a key that stays the same across restarts, set ` + "`--jwt-jwks`" + ` to a JSON Web Key Set file. If it does not exist, it is created with a new key of the algorithm set with ` + "`--jwt-algorithm`" + ` (for example RS256 or EdDSA). The token is valid for one minute, which ` + "`--jwt-ttl`" + ` changes, and ` + "`--jwt-audience`" + ` sets its audience:
+
+		$ {{.CommandPath}} --jwt-jwks jwks.json --jwt-algorithm RS256 \
+			--jwt-ttl 5m --jwt-audience https://api.example.org \
+			--project <project-id-or-slug> http://localhost:3000
+
+To send a compact claim set instead of the whole session, for example the same one your production gateway sends, map the session to claims using JSON pointers:
+
+		$ {{.CommandPath}} --jwt-claim email=/identity/traits/email --jwt-claim sid=/id ...
+
+or a Jsonnet file, which receives the session as ` + "`std.extVar('session')`" + `:
+
+		local session = std.extVar('session');
+		{
+		  email: session.identity.traits.email,
+		  verified: std.length([a for a in session.identity.verifiable_addresses if a.verified]) > 0,
+		}
+
+		$ {{.CommandPath}} --jwt-claims claims.jsonnet ...
+
+The ` + "`sub`" + `, ` + "`iss`" + `, ` + "`aud`" + `, ` + "`exp`" + `, ` + "`nbf`" + `, ` + "`iat`" + `, and ` + "`jti`" + ` claims are always set, unless the mapped claims replace them.
+
+To create the JWT, the proxy looks up the Ory Session of the request's cookie or session token. The result is cached for 30 seconds, or until the session expires if that is sooner, so that page loads firing many requests do not look up the session for each. Logging out or submitting a self-service flow through the proxy clears the cache. Use ` + "`--session-cache-ttl`" + ` to change the duration, or set it to 0 to disable the cache. With ` + "`--debug`" + `, the cache hits and misses are printed.
+
 An example JWT payload:
 
 		{
@@ -152,26 +270,31 @@ An example JWT payload:
 `,
 
 		RunE: func(cmd *cobra.Command, args []string) error {
+			h, err := client.NewCobraCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+			if conf.tlsPrintCA {
+				return printCA(h, cmd.OutOrStdout())
+			}
 			conf.upstream = args[0]
+			if err := loadConfigFile(&conf); err != nil {
+				return err
+			}
 
-			selfURLString := fmt.Sprintf("http://localhost:%d", conf.port)
+			selfURLString := fmt.Sprintf("%s://localhost:%d", conf.scheme(), conf.port)
 			if len(args) == 2 {
 				selfURLString = args[1]
 			}
 
-			var err error
 			conf.publicURL, err = url.ParseRequestURI(selfURLString)
 			if err != nil {
 				return err
 			}
 
+			conf.fallbackRedirectTo.URL = *conf.publicURL
 			if conf.defaultRedirectTo.String() == "" {
-				conf.defaultRedirectTo.URL = *conf.publicURL
-			}
-
-			h, err := client.NewCobraCommandHelper(cmd)
-			if err != nil {
-				return err
+				conf.defaultRedirectTo = conf.fallbackRedirectTo
 			}
 
 			return runReverseProxy(cmd.Context(), h, cmd.ErrOrStderr(), &conf, "proxy")
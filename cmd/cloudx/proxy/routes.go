@@ -0,0 +1,185 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"cmp"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	RouteFlag      = "route"
+	RoutesFileFlag = "routes-file"
+)
+
+type (
+	// route forwards the requests matching its host and path to an upstream.
+	route struct {
+		// Host matches the host of the request, without the port. A leading
+		// "*." matches all subdomains. An empty host matches every host.
+		Host string `yaml:"host"`
+		// Path matches requests to the path and below it.
+		Path     string `yaml:"path"`
+		Upstream string `yaml:"upstream"`
+		// StripPrefix removes the path before forwarding the request.
+		StripPrefix bool `yaml:"strip_prefix"`
+		// JWT overrides whether the Ory Session is passed to the upstream as a
+		// JSON Web Token. Unset, it is unless --no-jwt is set.
+		JWT *bool `yaml:"jwt"`
+
+		upstream *url.URL
+	}
+	routesFile struct {
+		Routes []route `yaml:"routes"`
+	}
+)
+
+func registerRouteFlags(conf *config, flags *pflag.FlagSet) {
+	flags.StringArrayVar(&conf.routeFlags, RouteFlag, nil, "Forward the requests matching `[host]/path=url[,jwt=true|false][,strip-prefix]` to another upstream than the application URL. Can be repeated.")
+	flags.StringVar(&conf.routesFile, RoutesFileFlag, "", "A YAML or JSON file with the routes to forward to other upstreams than the application URL.")
+}
+
+// parseRouteFlag parses a route given as `[host]/path=url[,option...]`.
+func parseRouteFlag(value string) (route, error) {
+	pattern, target, ok := strings.Cut(value, "=")
+	if !ok {
+		return route{}, errors.Errorf("route %q must have the format [host]/path=url", value)
+	}
+
+	var r route
+	if i := strings.Index(pattern, "/"); i >= 0 {
+		r.Host, r.Path = pattern[:i], pattern[i:]
+	} else {
+		r.Host, r.Path = pattern, "/"
+	}
+
+	options := strings.Split(target, ",")
+	r.Upstream = options[0]
+	for _, o := range options[1:] {
+		name, arg, _ := strings.Cut(o, "=")
+		switch name {
+		case "jwt":
+			enabled, err := strconv.ParseBool(cmp.Or(arg, "true"))
+			if err != nil {
+				return route{}, errors.Errorf("route %q has an invalid jwt option: %s", value, err)
+			}
+			r.JWT = &enabled
+		case "no-jwt":
+			r.JWT = new(false)
+		case "strip-prefix":
+			r.StripPrefix = true
+		default:
+			return route{}, errors.Errorf("route %q has the unknown option %q", value, name)
+		}
+	}
+	return r, nil
+}
+
+// loadRoutes returns the routes of the proxy, most specific first. The
+// application URL is the route of last resort.
+func loadRoutes(conf *config) ([]route, error) {
+	var routes []route
+	if conf.routesFile != "" {
+		raw, err := os.ReadFile(conf.routesFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read the routes file")
+		}
+		var f routesFile
+		if err := yaml.Unmarshal(raw, &f); err != nil {
+			return nil, errors.Wrapf(err, "unable to parse the routes file %s", conf.routesFile)
+		}
+		routes = append(routes, f.Routes...)
+	}
+	for _, v := range conf.routeFlags {
+		r, err := parseRouteFlag(v)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, r)
+	}
+	routes = append(routes, route{Path: "/", Upstream: conf.upstream})
+
+	for k := range routes {
+		r := &routes[k]
+		r.Host = strings.ToLower(r.Host)
+		r.Path = cmp.Or(r.Path, "/")
+		if !strings.HasPrefix(r.Path, "/") {
+			return nil, errors.Errorf("the path %q of a route must start with a slash", r.Path)
+		}
+		if r.Path != "/" {
+			r.Path = strings.TrimSuffix(r.Path, "/")
+		}
+		if conf.pathPrefix != "" && matchesPath(conf.pathPrefix, r.Path) {
+			return nil, errors.Errorf("the path %q of a route is reserved for Ory", r.Path)
+		}
+
+		var err error
+		r.upstream, err = url.ParseRequestURI(r.Upstream)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse the upstream URL of route %s%s", r.Host, r.Path)
+		} else if (r.upstream.Scheme != "http" && r.upstream.Scheme != "https") || r.upstream.Host == "" {
+			return nil, errors.Errorf("unable to parse the upstream URL of route %s%s: %q is not an absolute http(s) URL", r.Host, r.Path, r.Upstream)
+		}
+	}
+
+	// Prefer routes for a host over those for all hosts, and longer paths over
+	// shorter ones. The sort is stable, so the application URL stays last.
+	slices.SortStableFunc(routes, func(a, b route) int {
+		if (a.Host == "") != (b.Host == "") {
+			if a.Host == "" {
+				return 1
+			}
+			return -1
+		}
+		return len(b.Path) - len(a.Path)
+	})
+	return routes, nil
+}
+
+// matchRoute returns the route of the request.
+func matchRoute(routes []route, r *http.Request) *route {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+
+	for k := range routes {
+		if matchesHost(routes[k].Host, host) && matchesPath(routes[k].Path, r.URL.Path) {
+			return &routes[k]
+		}
+	}
+	return nil
+}
+
+func matchesHost(pattern, host string) bool {
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		return strings.HasSuffix(host, "."+suffix)
+	}
+	return pattern == "" || pattern == host
+}
+
+// matchesPath reports whether p is the prefix or below it.
+func matchesPath(prefix, p string) bool {
+	return prefix == "/" || p == prefix || strings.HasPrefix(p, prefix+"/")
+}
+
+// jwt reports whether the Ory Session is passed to the route's upstream as a
+// JSON Web Token.
+func (r *route) jwt(conf *config) bool {
+	if r.JWT != nil {
+		return *r.JWT
+	}
+	return !conf.noJWT
+}
@@ -0,0 +1,81 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutes(t *testing.T) {
+	routesFile := filepath.Join(t.TempDir(), "routes.yaml")
+	require.NoError(t, os.WriteFile(routesFile, []byte(`routes:
+  - path: /api/admin/
+    upstream: http://localhost:8081
+    strip_prefix: true
+  - host: "*.tenant.localhost"
+    upstream: http://localhost:7000
+    jwt: true
+`), 0600))
+
+	conf := &config{
+		upstream:   "http://localhost:3000",
+		pathPrefix: "/.ory",
+		noJWT:      true,
+		routesFile: routesFile,
+		routeFlags: []string{
+			"/api=http://localhost:8080,jwt",
+			"Admin.localhost/=http://localhost:9000,no-jwt",
+		},
+	}
+	routes, err := loadRoutes(conf)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		url, upstream string
+		jwt           bool
+	}{
+		{url: "http://localhost:4000/", upstream: "localhost:3000"},
+		{url: "http://localhost:4000/apix", upstream: "localhost:3000"},
+		{url: "http://localhost:4000/api", upstream: "localhost:8080", jwt: true},
+		{url: "http://localhost:4000/api/users", upstream: "localhost:8080", jwt: true},
+		{url: "http://localhost:4000/api/admin/users", upstream: "localhost:8081"},
+		{url: "http://admin.localhost:4000/api", upstream: "localhost:9000"},
+		{url: "http://a.tenant.localhost:4000/", upstream: "localhost:7000", jwt: true},
+		{url: "http://tenant.localhost:4000/", upstream: "localhost:3000"},
+	} {
+		t.Run("url="+tc.url, func(t *testing.T) {
+			r := matchRoute(routes, httptest.NewRequest("GET", tc.url, nil))
+			require.NotNil(t, r)
+			assert.Equal(t, tc.upstream, r.upstream.Host)
+			assert.Equal(t, tc.jwt, r.jwt(conf))
+		})
+	}
+
+	admin := matchRoute(routes, httptest.NewRequest("GET", "http://localhost:4000/api/admin", nil))
+	assert.Equal(t, "/api/admin", admin.Path)
+	assert.True(t, admin.StripPrefix)
+
+	t.Run("case=invalid routes", func(t *testing.T) {
+		for _, tc := range []struct {
+			route, err string
+		}{
+			{route: "/api", err: "must have the format"},
+			{route: "/api=http://localhost:8080,cache", err: `unknown option "cache"`},
+			{route: "/api=http://localhost:8080,jwt=maybe", err: "invalid jwt option"},
+			{route: "/.ory/x=http://localhost:8080", err: "reserved for Ory"},
+			{route: "/api=localhost:8080", err: "unable to parse the upstream URL"},
+		} {
+			t.Run("route="+tc.route, func(t *testing.T) {
+				_, err := loadRoutes(&config{upstream: "http://localhost:3000", pathPrefix: "/.ory", routeFlags: []string{tc.route}})
+				assert.ErrorContains(t, err, tc.err)
+			})
+		}
+	})
+}
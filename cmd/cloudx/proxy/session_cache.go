@@ -0,0 +1,182 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/tidwall/gjson"
+)
+
+const SessionCacheTTLFlag = "session-cache-ttl"
+
+const defaultSessionCacheTTL = 30 * time.Second
+
+// maxSessionCacheEntries caps the sessions kept in memory. When the cache is
+// full, the entry expiring soonest makes room.
+const maxSessionCacheEntries = 10000
+
+func registerSessionCacheFlags(conf *config, flags *pflag.FlagSet) {
+	flags.DurationVar(&conf.sessionCacheTTL, SessionCacheTTLFlag, defaultSessionCacheTTL, "How long to cache the Ory Session of a cookie or session token, instead of looking it up for every request. The session is never cached beyond its expiry. Set to 0 to disable the cache.")
+}
+
+type (
+	// sessionCache caches the result of looking up the session of a request
+	// by the credentials it was looked up with.
+	sessionCache struct {
+		ttl time.Duration
+		// debug receives the hit and miss counters, if set.
+		debug io.Writer
+		now   func() time.Time
+
+		mu           sync.Mutex
+		entries      map[string]sessionCacheEntry
+		hits, misses int
+		// swept is when the expired entries were last removed.
+		swept time.Time
+	}
+	sessionCacheEntry struct {
+		session json.RawMessage
+		expires time.Time
+	}
+)
+
+func newSessionCache(ttl time.Duration, debug io.Writer) *sessionCache {
+	return &sessionCache{
+		ttl:     ttl,
+		debug:   debug,
+		now:     time.Now,
+		entries: make(map[string]sessionCacheEntry),
+	}
+}
+
+// isSessionCookie reports whether the cookie holds an Ory session, which is
+// ory_session_<slug> on Ory Network and ory_kratos_session for Ory Kratos.
+func isSessionCookie(name string) bool {
+	return strings.HasPrefix(name, "ory_session_") || name == "ory_kratos_session"
+}
+
+// sessionCacheKey returns the cache key of the session credentials checkSession
+// forwards. Other cookies, such as analytics or CSRF cookies, do not change the
+// session and are left out. The credentials are hashed so they are not kept in
+// memory.
+func sessionCacheKey(r *http.Request) string {
+	h := sha256.New()
+	cookies := r.Cookies()
+	slices.SortFunc(cookies, func(a, b *http.Cookie) int { return strings.Compare(a.Name, b.Name) })
+	for _, c := range cookies {
+		if isSessionCookie(c.Name) {
+			_, _ = fmt.Fprintf(h, "Cookie\x00%s=%s\x00", c.Name, c.Value)
+		}
+	}
+	for _, name := range []string{"Authorization", "X-Session-Token"} {
+		_, _ = fmt.Fprintf(h, "%s\x00%s\x00", name, r.Header.Get(name))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// get returns the cached session of the request, if any.
+func (c *sessionCache) get(r *http.Request) (json.RawMessage, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := sessionCacheKey(r)
+	e, ok := c.entries[key]
+	if ok && !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		ok = false
+	}
+	if ok {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	if c.debug != nil {
+		result := "miss"
+		if ok {
+			result = "hit"
+		}
+		_, _ = fmt.Fprintf(c.debug, "session cache %s for %s (%d hits, %d misses)\n", result, r.URL, c.hits, c.misses)
+	}
+	return e.session, ok
+}
+
+// set caches the session of the request. An active session is cached at most
+// until it expires.
+func (c *sessionCache) set(r *http.Request, session json.RawMessage) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	expires := c.now().Add(c.ttl)
+	if at := gjson.GetBytes(session, "expires_at"); gjson.GetBytes(session, "active").Bool() && at.Exists() {
+		if t := at.Time(); t.Before(expires) {
+			expires = t
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := sessionCacheKey(r)
+	if _, ok := c.entries[key]; !ok {
+		c.makeRoom()
+	}
+	c.entries[key] = sessionCacheEntry{session: session, expires: expires}
+}
+
+// makeRoom removes the expired entries once per TTL, and evicts the entry
+// expiring soonest if the cache is still full. The caller must hold the lock.
+func (c *sessionCache) makeRoom() {
+	now := c.now()
+	if len(c.entries) >= maxSessionCacheEntries || !now.Before(c.swept.Add(c.ttl)) {
+		maps.DeleteFunc(c.entries, func(_ string, e sessionCacheEntry) bool { return !now.Before(e.expires) })
+		c.swept = now
+	}
+	if len(c.entries) < maxSessionCacheEntries {
+		return
+	}
+
+	var soonest string
+	for key, e := range c.entries {
+		if soonest == "" || e.expires.Before(c.entries[soonest].expires) {
+			soonest = key
+		}
+	}
+	delete(c.entries, soonest)
+}
+
+// invalidate drops all cached sessions. The request changing a session does not
+// necessarily carry the credentials the session was cached by, for example when
+// logging out using the API, so nothing can be kept.
+func (c *sessionCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+// changesSession reports whether the request to Ory may end or change a
+// session: logging out, and submitting a self-service flow.
+func changesSession(conf *config, r *http.Request) bool {
+	p := strings.TrimPrefix(r.URL.Path, conf.pathPrefix)
+	if strings.HasPrefix(p, "/self-service/logout") || (strings.HasPrefix(p, "/sessions") && r.Method == http.MethodDelete) {
+		return true
+	}
+	return strings.HasPrefix(p, "/self-service/") && r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions
+}
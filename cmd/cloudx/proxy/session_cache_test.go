@@ -0,0 +1,149 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/herodot"
+)
+
+func TestSessionCache(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	var debug bytes.Buffer
+	c := newSessionCache(time.Minute, &debug)
+	c.now = func() time.Time { return now }
+
+	withCookie := func(session string) *http.Request {
+		r := httptest.NewRequest("GET", "http://localhost:4000/", nil)
+		if session != "" {
+			r.Header.Set("Cookie", "ory_session_example="+session)
+		}
+		return r
+	}
+
+	_, ok := c.get(withCookie("a"))
+	assert.False(t, ok)
+
+	c.set(withCookie("a"), json.RawMessage(`{"active": true, "expires_at": "2026-10-01T12:00:30Z"}`))
+	c.set(withCookie("b"), json.RawMessage(`{"active": true, "expires_at": "2026-10-02T12:00:00Z"}`))
+	c.set(withCookie(""), json.RawMessage(`{"error": {"code": 401}}`))
+
+	_, ok = c.get(withCookie("a"))
+	assert.True(t, ok)
+	_, ok = c.get(withCookie(""))
+	assert.True(t, ok, "the lack of a session is cached as well")
+
+	now = now.Add(40 * time.Second)
+	_, ok = c.get(withCookie("a"))
+	assert.False(t, ok, "a session is not cached beyond its expiry")
+	_, ok = c.get(withCookie("b"))
+	assert.True(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = c.get(withCookie("b"))
+	assert.False(t, ok, "a session is not cached beyond the TTL")
+
+	assert.Equal(t, 3, c.hits)
+	assert.Equal(t, 3, c.misses)
+	assert.Contains(t, debug.String(), "session cache hit for http://localhost:4000/ (3 hits, 2 misses)")
+	assert.NotContains(t, debug.String(), `"a"`, "the credentials must not be printed")
+
+	t.Run("case=only session credentials are part of the key", func(t *testing.T) {
+		r := withCookie("a")
+		r.Header.Set("Cookie", "_ga=GA1.1.123; ory_session_example=a; csrf_token_abc=rotated")
+		assert.Equal(t, sessionCacheKey(withCookie("a")), sessionCacheKey(r))
+		assert.NotEqual(t, sessionCacheKey(withCookie("a")), sessionCacheKey(withCookie("b")))
+
+		r = withCookie("")
+		r.Header.Set("X-Session-Token", "token")
+		assert.NotEqual(t, sessionCacheKey(withCookie("")), sessionCacheKey(r))
+	})
+
+	t.Run("case=expired entries are swept", func(t *testing.T) {
+		c := newSessionCache(time.Minute, nil)
+		now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		c.now = func() time.Time { return now }
+		for _, session := range []string{"a", "b", "c"} {
+			c.set(withCookie(session), json.RawMessage(`{"active": false}`))
+		}
+		require.Len(t, c.entries, 3)
+
+		now = now.Add(2 * time.Minute)
+		c.set(withCookie("d"), json.RawMessage(`{"active": false}`))
+		assert.Len(t, c.entries, 1, "sessions that are never looked up again are removed")
+	})
+
+	t.Run("case=size is capped", func(t *testing.T) {
+		c := newSessionCache(time.Hour, nil)
+		now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		c.now = func() time.Time { return now }
+		for i := range maxSessionCacheEntries + 10 {
+			now = now.Add(time.Millisecond)
+			c.set(withCookie(fmt.Sprint(i)), json.RawMessage(`{"active": false}`))
+		}
+		assert.Len(t, c.entries, maxSessionCacheEntries)
+		_, ok := c.get(withCookie("0"))
+		assert.False(t, ok, "the entry expiring soonest is evicted")
+		_, ok = c.get(withCookie(fmt.Sprint(maxSessionCacheEntries + 9)))
+		assert.True(t, ok)
+	})
+
+	t.Run("case=disabled", func(t *testing.T) {
+		c := newSessionCache(0, nil)
+		c.set(withCookie("a"), json.RawMessage(`{"active": true}`))
+		_, ok := c.get(withCookie("a"))
+		assert.False(t, ok)
+	})
+}
+
+func TestSessionCacheInvalidation(t *testing.T) {
+	var lookups atomic.Int32
+	ory := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookups.Add(1)
+		_, _ = w.Write([]byte(`{"id": "821f5a53-a0b3-41fa-9c62-764560fa4406", "active": true, "identity": {"id": "18aafd3e-b00c-4b19-81c8-351e38705126"}}`))
+	}))
+	t.Cleanup(ory.Close)
+	endpoint, err := url.Parse(ory.URL)
+	require.NoError(t, err)
+
+	conf := &config{pathPrefix: "/.ory", jwtTTL: time.Minute}
+	sig, keys, err := newJWTSigner(conf)
+	require.NoError(t, err)
+	mapClaims, err := newClaimsMapper(conf)
+	require.NoError(t, err)
+	routes, err := loadRoutes(&config{upstream: "http://localhost:3000"})
+	require.NoError(t, err)
+	mw := sessionToJWTMiddleware(conf, routes, herodot.NewJSONWriter(nil), keys, sig, mapClaims, newSessionCache(time.Minute, nil), nil, nil, endpoint)
+
+	request := func(method, path string) {
+		r := httptest.NewRequest(method, "http://localhost:4000"+path, nil)
+		r.Header.Set("Cookie", "ory_session=abc")
+		mw(httptest.NewRecorder(), r, func(http.ResponseWriter, *http.Request) {})
+	}
+
+	request("GET", "/")
+	request("GET", "/app.js")
+	request("GET", "/.ory/self-service/settings/browser")
+	assert.EqualValues(t, 1, lookups.Load())
+
+	request("GET", "/.ory/self-service/logout?token=abc")
+	request("GET", "/")
+	assert.EqualValues(t, 2, lookups.Load())
+
+	request("POST", "/.ory/self-service/settings?flow=abc")
+	request("GET", "/")
+	assert.EqualValues(t, 3, lookups.Load())
+}
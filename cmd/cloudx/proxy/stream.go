@@ -0,0 +1,72 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"time"
+
+	"github.com/ory/x/proxy"
+)
+
+// isStream tells whether the request opens a long-lived connection: a
+// WebSocket or another protocol upgrade, or Server-Sent Events.
+func isStream(r *http.Request) bool {
+	if r.Header.Get("Upgrade") != "" {
+		for _, v := range r.Header.Values("Connection") {
+			for token := range strings.SplitSeq(v, ",") {
+				if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+					return true
+				}
+			}
+		}
+	}
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+// withoutStreamDeadlines lifts the server's read and write timeouts for
+// streams, which would otherwise close them after two minutes.
+func withoutStreamDeadlines(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isStream(r) {
+			rc := http.NewResponseController(w)
+			_ = rc.SetReadDeadline(time.Time{})
+			_ = rc.SetWriteDeadline(time.Time{})
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// newStreamProxy forwards streams to the upstream routes. Unlike the proxy
+// for all other requests, it neither buffers nor rewrites bodies, and hands
+// upgraded connections over to the upstream.
+func newStreamProxy(conf *config, routes []route, state *proxyState) http.Handler {
+	rewrite := reqMiddleware(conf, state.oryURL, state.apiKey, state.rateLimitName, state.rateLimitValue)
+	return &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			target := matchRoute(routes, r.In)
+			r.Out.URL.Scheme, r.Out.URL.Host = target.upstream.Scheme, target.upstream.Host
+			if target.StripPrefix && target.Path != "/" {
+				r.Out.URL.Path = strings.TrimPrefix(r.Out.URL.Path, target.Path)
+				r.Out.URL.RawPath = ""
+			}
+			_, _ = rewrite(r, &proxy.HostConfig{UpstreamHost: target.upstream.Host}, nil)
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			for _, h := range upstreamCORSHeaders {
+				resp.Header.Del(h)
+			}
+			return nil
+		},
+		// Flush every event right away.
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			_, _ = fmt.Fprintf(state.stdErr, "Unable to forward the stream %s: %s\n", r.URL, err)
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/herodot"
+)
+
+func TestStreams(t *testing.T) {
+	ory := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "821f5a53-a0b3-41fa-9c62-764560fa4406", "active": true, "identity": {"id": "18aafd3e-b00c-4b19-81c8-351e38705126"}}`))
+	}))
+	t.Cleanup(ory.Close)
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/events":
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			for i := range 3 {
+				_, _ = fmt.Fprintf(w, "data: %d\n\n", i)
+				_ = http.NewResponseController(w).Flush()
+				time.Sleep(100 * time.Millisecond)
+			}
+		case "/socket":
+			if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			conn, rw, err := http.NewResponseController(w).Hijack()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+			_ = rw.Flush()
+			line, _ := rw.ReadString('\n')
+			_, _ = rw.WriteString("echo " + line)
+			_ = rw.Flush()
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	oryURL, err := url.Parse(ory.URL)
+	require.NoError(t, err)
+	publicURL, err := url.Parse("http://localhost:4000")
+	require.NoError(t, err)
+	conf := &config{
+		pathPrefix: "/.ory",
+		upstream:   upstream.URL,
+		publicURL:  publicURL,
+		logFormat:  logFormatText,
+		jwtTTL:     time.Minute,
+	}
+	signer, keys, err := newJWTSigner(conf)
+	require.NoError(t, err)
+	h, err := newProxyHandler(conf, &proxyState{
+		stdErr: io.Discard,
+		writer: herodot.NewJSONWriter(nil),
+		oryURL: oryURL,
+		signer: signer,
+		keys:   keys,
+	})
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(withoutStreamDeadlines(h))
+	// The events take longer than the timeouts.
+	server.Config.ReadTimeout, server.Config.WriteTimeout = 150*time.Millisecond, 150*time.Millisecond
+	server.Start()
+	t.Cleanup(server.Close)
+
+	t.Run("case=server-sent events", func(t *testing.T) {
+		req, err := http.NewRequest("GET", server.URL+"/events", nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "text/event-stream")
+		res, err := server.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Empty(t, res.Header.Values("Access-Control-Allow-Origin"), "the proxy answers CORS itself")
+
+		start := time.Now()
+		events := bufio.NewReader(res.Body)
+		first, err := events.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "data: 0\n", first)
+		assert.Less(t, time.Since(start), 100*time.Millisecond, "the first event must arrive before the stream ends")
+
+		rest, err := io.ReadAll(events)
+		require.NoError(t, err)
+		assert.Equal(t, "\ndata: 1\n\ndata: 2\n\n", string(rest))
+	})
+
+	t.Run("case=upgrade", func(t *testing.T) {
+		conn, err := net.Dial("tcp", server.Listener.Addr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = fmt.Fprint(conn, "GET /socket HTTP/1.1\r\nHost: localhost:4000\r\nConnection: Upgrade\r\nUpgrade: echo\r\nCookie: ory_session=abc\r\n\r\n")
+		require.NoError(t, err)
+		rw := bufio.NewReader(conn)
+		res, err := http.ReadResponse(rw, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusSwitchingProtocols, res.StatusCode, "the JWT is added to the handshake")
+
+		time.Sleep(200 * time.Millisecond)
+		_, err = fmt.Fprint(conn, "ping\n")
+		require.NoError(t, err)
+		line, err := rw.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "echo ping\n", line)
+	})
+}
@@ -0,0 +1,230 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/ory/cli/cmd/cloudx/client"
+)
+
+const (
+	TLSFlag        = "tls"
+	TLSCertFlag    = "tls-cert"
+	TLSKeyFlag     = "tls-key"
+	TLSPrintCAFlag = "tls-print-ca"
+)
+
+const (
+	caCertFile = "ca.pem"
+	caKeyFile  = "ca-key.pem"
+
+	caValidity   = 10 * 365 * 24 * time.Hour
+	leafValidity = 30 * 24 * time.Hour
+)
+
+func registerTLSFlags(conf *config, flags *pflag.FlagSet) {
+	flags.BoolVar(&conf.tls, TLSFlag, false, "Serve HTTPS using a certificate for localhost and the public URL's host, issued by a local certificate authority the CLI creates once.")
+	flags.StringVar(&conf.tlsCert, TLSCertFlag, "", "Serve HTTPS using this PEM encoded certificate instead of one issued by the local certificate authority. Requires --"+TLSKeyFlag+".")
+	flags.StringVar(&conf.tlsKey, TLSKeyFlag, "", "The PEM encoded private key of the certificate set with --"+TLSCertFlag+".")
+	flags.BoolVar(&conf.tlsPrintCA, TLSPrintCAFlag, false, "Print the path of the local certificate authority's certificate, creating it if necessary, and exit. Install it as trusted to make browsers accept the proxy's certificate.")
+}
+
+// useTLS reports whether the proxy serves HTTPS.
+func (c *config) useTLS() bool {
+	return c.tls || c.tlsCert != "" || c.tlsKey != ""
+}
+
+func (c *config) scheme() string {
+	if c.useTLS() {
+		return "https"
+	}
+	return "http"
+}
+
+// argsUnlessPrintingCA validates the arguments using validate, unless only the
+// local certificate authority's path is printed.
+func argsUnlessPrintingCA(conf *config, validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if conf.tlsPrintCA {
+			return cobra.NoArgs(cmd, args)
+		}
+		return validate(cmd, args)
+	}
+}
+
+// caDir returns the directory the local certificate authority is stored in.
+func caDir(h *client.CommandHelper) string {
+	return h.ConfigLocation() + ".tls"
+}
+
+// printCA prints the path of the local certificate authority's certificate,
+// creating the certificate authority if necessary.
+func printCA(h *client.CommandHelper, w io.Writer) error {
+	if _, _, err := loadOrCreateCA(caDir(h)); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(w, filepath.Join(caDir(h), caCertFile))
+	return nil
+}
+
+// tlsCertificate returns the certificate the proxy serves. Unless one was set
+// using --tls-cert and --tls-key, it is issued for the hosts by the local
+// certificate authority stored in caDir.
+func tlsCertificate(conf *config, caDir string, hosts []string) (*tls.Certificate, error) {
+	if conf.tlsCert != "" || conf.tlsKey != "" {
+		if conf.tlsCert == "" || conf.tlsKey == "" {
+			return nil, errors.Errorf("both --%s and --%s must be set", TLSCertFlag, TLSKeyFlag)
+		}
+		cert, err := tls.LoadX509KeyPair(conf.tlsCert, conf.tlsKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load the TLS certificate")
+		}
+		return &cert, nil
+	}
+
+	ca, caKey, err := loadOrCreateCA(caDir)
+	if err != nil {
+		return nil, err
+	}
+	return issueCertificate(ca, caKey, hosts)
+}
+
+// loadOrCreateCA loads the local certificate authority, creating it on first
+// use. It is kept across runs so it only has to be trusted once.
+func loadOrCreateCA(dir string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certPath, keyPath := filepath.Join(dir, caCertFile), filepath.Join(dir, caKeyFile)
+
+	certPEM, certErr := os.ReadFile(certPath)
+	keyPEM, keyErr := os.ReadFile(keyPath)
+	if certErr == nil && keyErr == nil {
+		pair, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "unable to load the local certificate authority from %s, remove the directory to create a new one", dir)
+		}
+		key, ok := pair.PrivateKey.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, nil, errors.Errorf("the local certificate authority in %s uses an unsupported key, remove the directory to create a new one", dir)
+		}
+		cert, err := x509.ParseCertificate(pair.Certificate[0])
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		if time.Now().After(cert.NotAfter) {
+			return nil, nil, errors.Errorf("the local certificate authority in %s expired, remove the directory to create a new one", dir)
+		}
+		return cert, key, nil
+	} else if !os.IsNotExist(certErr) && certErr != nil {
+		return nil, nil, errors.WithStack(certErr)
+	} else if !os.IsNotExist(keyErr) && keyErr != nil {
+		return nil, nil, errors.WithStack(keyErr)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to generate the certificate authority's key")
+	}
+	hostname, _ := os.Hostname()
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          newSerialNumber(),
+		Subject:               pkix.Name{Organization: []string{"Ory CLI"}, CommonName: fmt.Sprintf("Ory CLI local development CA (%s)", hostname)},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to create the certificate authority")
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	rawKey, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: rawKey}), 0600); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	return cert, key, nil
+}
+
+// issueCertificate issues a short-lived certificate for the hosts, which are
+// host names or IP addresses.
+func issueCertificate(ca *x509.Certificate, caKey *ecdsa.PrivateKey, hosts []string) (*tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to generate the certificate's key")
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: newSerialNumber(),
+		Subject:      pkix.Name{Organization: []string{"Ory CLI"}, CommonName: hosts[0]},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(leafValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to issue the certificate")
+	}
+	return &tls.Certificate{Certificate: [][]byte{der, ca.Raw}, PrivateKey: key}, nil
+}
+
+func newSerialNumber() *big.Int {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		panic(err)
+	}
+	return serial
+}
+
+// certificateHosts returns the hosts the proxy's certificate is issued for.
+func certificateHosts(conf *config) []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if h := conf.publicURL.Hostname(); h != "" && !slices.Contains(hosts, h) {
+		hosts = append(hosts, h)
+	}
+	return hosts
+}
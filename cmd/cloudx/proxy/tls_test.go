@@ -0,0 +1,72 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSCertificate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "tls")
+	conf := &config{tls: true, publicURL: &url.URL{Scheme: "https", Host: "gateway.local:5000"}}
+
+	cert, err := tlsCertificate(conf, dir, certificateHosts(conf))
+	require.NoError(t, err)
+
+	fi, err := os.Stat(filepath.Join(dir, caKeyFile))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	caPEM, err := os.ReadFile(filepath.Join(dir, caCertFile))
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(caPEM))
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	for _, host := range []string{"localhost", "127.0.0.1", "::1", "gateway.local"} {
+		_, err := leaf.Verify(x509.VerifyOptions{DNSName: host, Roots: roots})
+		assert.NoError(t, err, host)
+	}
+	_, err = leaf.Verify(x509.VerifyOptions{DNSName: "example.org", Roots: roots})
+	assert.Error(t, err)
+
+	t.Run("case=reuses the certificate authority", func(t *testing.T) {
+		cert, err := tlsCertificate(conf, dir, certificateHosts(conf))
+		require.NoError(t, err)
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, err)
+		_, err = leaf.Verify(x509.VerifyOptions{DNSName: "localhost", Roots: roots})
+		assert.NoError(t, err)
+	})
+
+	t.Run("case=own certificate", func(t *testing.T) {
+		certPath, keyPath := filepath.Join(t.TempDir(), "cert.pem"), filepath.Join(t.TempDir(), "key.pem")
+		require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0600))
+		rawKey, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: rawKey}), 0600))
+
+		own, err := tlsCertificate(&config{tlsCert: certPath, tlsKey: keyPath}, dir, nil)
+		require.NoError(t, err)
+		assert.Equal(t, cert.Certificate[0], own.Certificate[0])
+
+		_, err = tlsCertificate(&config{tlsCert: certPath}, dir, nil)
+		assert.ErrorContains(t, err, "--tls-key")
+	})
+
+	t.Run("case=scheme", func(t *testing.T) {
+		assert.Equal(t, "https", conf.scheme())
+		assert.Equal(t, "https", (&config{tlsCert: "cert.pem", tlsKey: "key.pem"}).scheme())
+		assert.Equal(t, "http", (&config{}).scheme())
+	})
+}
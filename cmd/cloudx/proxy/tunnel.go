@@ -23,7 +23,7 @@ func NewTunnelCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "tunnel <application-url> [<tunnel-url>]",
 		Short: "Mirror Ory APIs on your local machine for local development and testing",
-		Args:  cobra.RangeArgs(1, 2),
+		Args:  argsUnlessPrintingCA(&conf, cobra.RangeArgs(1, 2)),
 		Example: `{{.CommandPath}} http://localhost:3000
 `,
 		Long: fmt.Sprintf(`The Ory Tunnel mirrors Ory APIs on your local machine, allowing seamless development and testing. This setup is required for features such as CORS and cookie support, making it possible for Ory and your application to share the same top-level domain during development. To use the tunnel, authentication via `+"`ORY_PROJECT_API_KEY`"+` or browser-based sign-in is required.
@@ -88,6 +88,55 @@ Example:
 
 Note: You cannot set a path in the `+"`tunnel-url`"+`.
 
+### HTTPS
+
+Browser features such as WebAuthn and passkeys, `+"`Secure`"+` and `+"`SameSite=None`"+` cookies, and OAuth2 providers requiring HTTPS redirect URIs only work in a secure context. Use the `+"`--tls`"+` flag to serve HTTPS:
+
+		$ {{.CommandPath}} --tls --project <project-id-or-slug> http://localhost:3000
+
+The certificate is issued for `+"`localhost`"+` and the host of the `+"`tunnel-url`"+` by a local certificate authority, which the CLI creates on first use and stores next to its configuration file. Install the certificate authority as trusted once to make browsers accept the certificate. To print its path:
+
+		$ {{.CommandPath}} --tls-print-ca
+
+To use your own certificate instead, set `+"`--tls-cert`"+` and `+"`--tls-key`"+`:
+
+		$ {{.CommandPath}} --tls-cert cert.pem --tls-key key.pem --project <project-id-or-slug> http://localhost:3000
+
+### Configuration file
+
+To keep the tunnel's settings in a file, write them to a YAML file whose keys are the names of the flags:
+
+		allowed-cors-origins:
+		  - https://www.example.org
+		cookie-domain: example.org
+
+		$ {{.CommandPath}} --config-file tunnel.yaml --project <project-id-or-slug> http://localhost:3000
+
+Flags take precedence over the file. Changes to the CORS origins and headers, the cookie domain, and the redirect URL apply while the tunnel is running. Other settings, such as the port, take effect on the next start.
+
+### Recording and replaying
+
+To run tests against your application without connecting to Ory, record the exchanges with Ory once using the `+"`--record`"+` flag:
+
+		$ {{.CommandPath}} --record cassette.har --project <project-id-or-slug> http://localhost:3000
+
+The cassette is an HTTP Archive (HAR) file, which you can also open in your browser's developer tools. Afterwards, replay it using the `+"`--replay`"+` flag, which neither needs a project nor an API key:
+
+		$ {{.CommandPath}} --replay cassette.har http://localhost:3000
+
+Requests are matched to the recorded ones by their method, path, query, and body. Per default, UUIDs such as flow IDs, CSRF tokens, and the redacted credentials are ignored, as they change between runs. Use `+"`--replay-match exact`"+` to match the UUIDs as well. Each recorded response is replayed once in the order it was recorded, and the last one repeatedly afterwards. Requests without a recorded response are answered with 404.
+
+Replay the cassette on the same tunnel URL and port it was recorded on, as the responses contain the tunnel's URLs. In the cassette, the credentials sent to Ory, such as cookies, passwords, one-time codes, and CSRF tokens, and the values of the session cookies set by Ory are redacted. Response bodies are recorded as they are, so review them before committing the cassette.
+
+### Health checks and metrics
+
+To wait for the tunnel in Docker Compose or other automated environments, use its health checks:
+
+		http://localhost:4000/.ory/proxy/health/alive
+		http://localhost:4000/.ory/proxy/health/ready
+
+The tunnel is alive once it accepts requests, and ready once your project answers and accepts the temporary API key. Use `+"`--metrics`"+` to serve Prometheus metrics at `+"`/.ory/proxy/metrics`"+`.
+
 ### Ports
 
 By default, the tunnel listens on port 4000. To change the port, use the --port flag:
@@ -120,7 +169,14 @@ $ {{.CommandPath}} --project <project-id-or-slug> \
 			if err != nil {
 				return err
 			}
-			selfURLString := fmt.Sprintf("http://localhost:%d", conf.port)
+			if conf.tlsPrintCA {
+				return printCA(h, cmd.OutOrStdout())
+			}
+			if err := loadConfigFile(&conf); err != nil {
+				return err
+			}
+
+			selfURLString := fmt.Sprintf("%s://localhost:%d", conf.scheme(), conf.port)
 			if len(args) == 2 {
 				selfURLString = args[1]
 			}
@@ -135,8 +191,9 @@ $ {{.CommandPath}} --project <project-id-or-slug> \
 			if err != nil {
 				return err
 			}
+			conf.fallbackRedirectTo.URL = *appURL
 			if conf.defaultRedirectTo.String() == "" {
-				conf.defaultRedirectTo.URL = *appURL
+				conf.defaultRedirectTo = conf.fallbackRedirectTo
 			}
 
 			return runReverseProxy(cmd.Context(), h, cmd.ErrOrStderr(), &conf, "tunnel")
@@ -144,6 +201,7 @@ $ {{.CommandPath}} --project <project-id-or-slug> \
 	}
 
 	registerConfigFlags(&conf, cmd.Flags())
+	registerCassetteFlags(&conf, cmd.Flags())
 	client.RegisterConfigFlag(cmd.Flags())
 	client.RegisterYesFlag(cmd.Flags())
 	client.RegisterProjectFlag(cmd.Flags())
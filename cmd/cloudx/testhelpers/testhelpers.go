@@ -64,12 +64,32 @@ func NewConfigFile(t testing.TB) string {
 	return filepath.Join(t.TempDir(), "config.json")
 }
 
+// configFile mirrors the layout of the Ory CLI configuration file.
+type configFile struct {
+	Version        string                    `json:"version"`
+	CurrentProfile string                    `json:"current_profile"`
+	Profiles       map[string]*client.Config `json:"profiles"`
+}
+
+// ReadConfig returns the currently selected profile of the configuration file.
 func ReadConfig(t testing.TB, configDir string) *client.Config {
 	f, err := os.ReadFile(configDir)
 	require.NoError(t, err)
-	var ac client.Config
-	require.NoError(t, json.Unmarshal(f, &ac))
-	return &ac
+	var cf configFile
+	require.NoError(t, json.Unmarshal(f, &cf))
+	require.Contains(t, cf.Profiles, cf.CurrentProfile)
+	return cf.Profiles[cf.CurrentProfile]
+}
+
+// WriteConfig writes the configuration file with ac as its only, default profile.
+func WriteConfig(t testing.TB, configDir string, ac *client.Config) {
+	raw, err := json.Marshal(configFile{
+		Version:        client.ConfigVersion,
+		CurrentProfile: client.DefaultProfile,
+		Profiles:       map[string]*client.Config{client.DefaultProfile: ac},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(configDir, raw, 0600))
 }
 
 var ErrAuthFlowTriggered = fmt.Errorf("flow triggered")
@@ -21,6 +21,7 @@ func NewUseCmd() *cobra.Command {
 	cmd.AddCommand(
 		project.NewUseProjectCmd(),
 		workspace.NewUseWorkspaceCmd(),
+		NewUseProfileCmd(),
 	)
 
 	client.RegisterConfigFlag(cmd.PersistentFlags())
@@ -33,15 +33,18 @@ func TestUseWorkspace(t *testing.T) {
 	newContext := func(t *testing.T, workspace string) (context.Context, string) {
 		t.Helper()
 
-		conf := map[string]any{
-			"version":          client.ConfigVersion,
+		profile := map[string]any{
 			"selected_project": project,
 			"access_token":     map[string]any{"access_token": accessToken, "token_type": "bearer"},
 		}
 		if workspace != "" {
-			conf["selected_workspace"] = workspace
+			profile["selected_workspace"] = workspace
 		}
-		raw, err := json.Marshal(conf)
+		raw, err := json.Marshal(map[string]any{
+			"version":         client.ConfigVersion,
+			"current_profile": client.DefaultProfile,
+			"profiles":        map[string]any{client.DefaultProfile: profile},
+		})
 		require.NoError(t, err)
 
 		location := testhelpers.NewConfigFile(t)
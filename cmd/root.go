@@ -27,24 +27,34 @@ func NewRootCmd() *cobra.Command {
 	c.AddCommand(newDevCommands()...)
 	c.AddCommand(
 		cloudx.NewAuthCmd(),
+		cloudx.NewApplyCmd(),
 		cloudx.NewCreateCmd(),
 		jsonnet.NewFormatCmd(),
 		jsonnet.NewLintCmd(),
 		cloudx.NewDeleteCmd(),
+		cloudx.NewDiffCmd(),
 		cloudx.NewGetCmd(),
+		cloudx.NewHistoryCmd(),
 		cloudx.NewUseCmd(),
 		cloudx.NewListCmd(),
+		cloudx.NewListenCmd(),
+		cloudx.NewExportCmd(),
 		cloudx.NewImportCmd(),
 		cloudx.NewOpenCmd(),
 		cloudx.NewPatchCmd(),
 		cloudx.NewParseCmd(),
 		cloudx.NewPauseCmd(),
 		cloudx.NewPerformCmd(),
+		cloudx.NewPlanCmd(),
+		cloudx.NewPromoteCmd(),
 		proxy.NewProxyCommand(),
 		proxy.NewTunnelCommand(),
 		cloudx.NewResumeCmd(),
+		cloudx.NewRollbackCmd(),
+		cloudx.NewRotateCmd(),
 		cloudx.NewUpdateCmd(),
 		cloudx.NewValidateCmd(),
+		cloudx.NewTestCmd(),
 		cloudx.NewRevokeCmd(),
 		cloudx.NewIntrospectCmd(),
 		cloudx.NewIsCmd(),